@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.mode`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterSecretsync is the Schema for the clustersecretsyncs API.
+// 集群级的 Secretsync，由平台统一管理，可将源 Secret 同步到任意命名空间
+type ClusterSecretsync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretsyncSpec   `json:"spec,omitempty"`
+	Status SecretsyncStatus `json:"status,omitempty"`
+}
+
+// SyncSpec 返回同步配置，便于控制器统一处理 Secretsync 与 ClusterSecretsync
+func (s *ClusterSecretsync) SyncSpec() *SecretsyncSpec {
+	return &s.Spec
+}
+
+// SyncStatus 返回同步状态，便于控制器统一处理 Secretsync 与 ClusterSecretsync
+func (s *ClusterSecretsync) SyncStatus() *SecretsyncStatus {
+	return &s.Status
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterSecretsyncList contains a list of ClusterSecretsync.
+type ClusterSecretsyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSecretsync `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterSecretsync{}, &ClusterSecretsyncList{})
+}
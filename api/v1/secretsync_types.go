@@ -23,36 +23,309 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// DeletionPolicy 决定 Secretsync 被删除时如何处理已同步到目标命名空间的 Secret
+// +kubebuilder:validation:Enum=Delete;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete 删除 Secretsync 时一并删除所有目标 Secret
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan 删除 Secretsync 时保留目标 Secret，仅解除控制器的管理
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// Secretsync 状态条件类型
+const (
+	// ConditionReady 表示所有目标命名空间均已同步到最新的源数据
+	ConditionReady = "Ready"
+	// ConditionSourceAvailable 表示源 Secret 是否可以读取
+	ConditionSourceAvailable = "SourceAvailable"
+	// ConditionDegraded 表示同步存在错误，需要人工介入或等待重试
+	ConditionDegraded = "Degraded"
+	// ConditionProgressing 表示控制器正在处理新的 spec
+	ConditionProgressing = "Progressing"
+	// ConditionSuspended 表示同步已被 spec.suspend 暂停
+	ConditionSuspended = "Suspended"
+)
+
+// Secretsync 状态条件原因
+const (
+	ReasonInvalidSpec         = "InvalidSpec"
+	ReasonSourceFound         = "SourceFound"
+	ReasonSourceNotFound      = "SourceNotFound"
+	ReasonSourceError         = "SourceError"
+	ReasonNamespaceListFailed = "NamespaceListFailed"
+	ReasonSynced              = "Synced"
+	ReasonSyncFailed          = "SyncFailed"
+	ReasonPruneFailed         = "PruneFailed"
+	ReasonTargetConflict      = "TargetConflict"
+	ReasonTargetsSkipped      = "TargetsSkipped"
+	ReasonReconciling         = "Reconciling"
+	ReasonReconcileComplete   = "ReconcileComplete"
+	ReasonSuspended           = "Suspended"
+	ReasonDriftDetected       = "DriftDetected"
+	ReasonChangesPending      = "ChangesPending"
+)
+
+// ConflictPolicy 决定目标命名空间中已存在同名、但不受控制器管理的 Secret 时如何处理
+// 是否受管理由 secretsync.example.com/managed-by 标签判断
+// +kubebuilder:validation:Enum=Overwrite;Adopt;Skip
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyOverwrite 覆盖已有 Secret 的数据，但不接管所有权，清理时不会删除它
+	ConflictPolicyOverwrite ConflictPolicy = "Overwrite"
+	// ConflictPolicyAdopt 接管已有 Secret：覆盖数据并添加管理标签，之后与控制器创建的 Secret 一致
+	ConflictPolicyAdopt ConflictPolicy = "Adopt"
+	// ConflictPolicySkip 保持已有 Secret 不变，并在状态中标记为冲突
+	ConflictPolicySkip ConflictPolicy = "Skip"
+)
+
+// SyncMode 决定控制器是否真正写入目标 Secret
+// +kubebuilder:validation:Enum=Enforce;Audit;DryRun
+type SyncMode string
+
+const (
+	// SyncModeEnforce 创建、更新并清理目标 Secret
+	SyncModeEnforce SyncMode = "Enforce"
+	// SyncModeAudit 只检测目标与源之间的偏差，并在状态与指标中报告，不写入
+	SyncModeAudit SyncMode = "Audit"
+	// SyncModeDryRun 计算完整的创建、更新与清理计划并发布在 status.plan 中，不写入
+	SyncModeDryRun SyncMode = "DryRun"
+)
+
+// PlanAction 是 DryRun 计划中的单个操作类型
+// +kubebuilder:validation:Enum=Create;Update;Prune
+type PlanAction string
+
+const (
+	// PlanActionCreate 目标 Secret 不存在，将被创建
+	PlanActionCreate PlanAction = "Create"
+	// PlanActionUpdate 目标 Secret 的数据或元数据将被更新
+	PlanActionUpdate PlanAction = "Update"
+	// PlanActionPrune 目标 Secret 已不在目标集合中，将被清理
+	PlanActionPrune PlanAction = "Prune"
+)
+
+// PlannedChange 描述 Enforce 模式下将对某个目标 Secret 执行的操作
+type PlannedChange struct {
+	// 目标命名空间
+	Namespace string `json:"namespace"`
+	// 目标 Secret 名称
+	Name string `json:"name"`
+	// 将执行的操作
+	Action PlanAction `json:"action"`
+	// 需要执行该操作的原因
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// KeyFilter 按 glob 模式筛选键，* 匹配任意字符，? 匹配单个字符
+type KeyFilter struct {
+	// 需要保留的键，为空表示保留全部
+	// +optional
+	Include []string `json:"include,omitempty"`
+	// 需要排除的键，优先级高于 include
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// KeyMapping 将源 Secret 中的一个键重命名为目标 Secret 中的键
+// 同一个源键可以出现在多条映射中，从而复制到多个目标键
+type KeyMapping struct {
+	// 源 Secret 中的键
+	From string `json:"from"`
+	// 目标 Secret 中的键
+	To string `json:"to"`
+}
+
+// SecretTemplate 描述如何基于源数据渲染目标 Secret 的数据
+type SecretTemplate struct {
+	// 键为目标 Secret 中的键，值为 Go text/template 模板
+	// 模板中可使用 .Data（源 Secret 的全部数据，已解码为字符串）、
+	// .Namespace.Name 与 .Namespace.Labels（目标命名空间），
+	// 以及 b64enc、b64dec、toJson、upper、lower 等函数
+	// +optional
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// TargetMetadata 描述目标 Secret 的标签与注解
+// 优先级从低到高依次为：从源复制的元数据、静态元数据、控制器自身的管理标签
+type TargetMetadata struct {
+	// 静态添加到目标 Secret 的标签
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// 静态添加到目标 Secret 的注解
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// 从源 Secret 复制标签的规则，未设置时不复制
+	// +optional
+	CopyLabels *KeyFilter `json:"copyLabels,omitempty"`
+	// 从源 Secret 复制注解的规则，未设置时不复制
+	// kubectl.kubernetes.io/last-applied-configuration 等系统注解始终不会被复制
+	// +optional
+	CopyAnnotations *KeyFilter `json:"copyAnnotations,omitempty"`
+}
+
 // SecretsyncSpec defines the desired state of Secretsync.
 type SecretsyncSpec struct {
-	// 源命名空间
+	// 源命名空间，命名空间级的 Secretsync 只能使用自身所在的命名空间
 	SourceNamespace string `json:"sourceNamespace"`
 	// 源 Secret 名称
 	SourceSecretName string `json:"sourceSecretName"`
 	// 目标命名空间选择器：支持 Labels 动态选择
 	TargetNamespaceSelector *metav1.LabelSelector `json:"targetNamespaceSelector,omitempty"`
-	// 目标 Secret 名称（可选，默认与源同名）
+	// 目标 Secret 名称（可选，默认与源同名）；命名空间级的 Secretsync 必须指定且不能与源同名
 	TargetSecretName string `json:"targetSecretName,omitempty"`
 	// 显式指定的目标命名空间列表
 	TargetNamespaces []string `json:"targetNamespaces,omitempty"`
+	// 按名称匹配目标命名空间的模式，默认为 glob（* 匹配任意字符，? 匹配单个字符），
+	// 以 / 包裹时按 RE2 正则处理，例如 /^team-[a-z]+-prod$/；模式需完整匹配命名空间名称
+	// +optional
+	TargetNamespacePatterns []string `json:"targetNamespacePatterns,omitempty"`
+	// 选择目标命名空间的 CEL 表达式，可使用 metadata.name、metadata.labels 与 metadata.annotations，例如
+	// 'tier' in metadata.annotations && metadata.annotations.tier == 'gold' && !metadata.name.startsWith('tmp-')
+	// +optional
+	TargetNamespaceExpression string `json:"targetNamespaceExpression,omitempty"`
+	// 排除的命名空间列表，在合并 targetNamespaces 与选择器结果之后应用
+	// +optional
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+	// 排除的命名空间选择器，匹配的命名空间不会作为目标
+	// +optional
+	ExcludeNamespaceSelector *metav1.LabelSelector `json:"excludeNamespaceSelector,omitempty"`
 	// 同步检查间隔时间（单位：秒），默认为 180 秒
 	SyncInterval int `json:"syncInterval,omitempty"`
+	// 暂停同步：不再写入或清理目标 Secret，也不再周期性调和；删除对象时仍按删除策略清理
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// 同步模式：Enforce 正常同步，Audit 只报告偏差，DryRun 只发布变更计划，默认为 Enforce
+	// +kubebuilder:default=Enforce
+	// +optional
+	Mode SyncMode `json:"mode,omitempty"`
+	// 删除策略：Delete 删除目标 Secret，Orphan 保留目标 Secret，默认为 Delete
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// 是否清理不再属于目标集合的 Secret（命名空间不再匹配或目标名称变更），默认为 true
+	// +kubebuilder:default=true
+	// +optional
+	Prune *bool `json:"prune,omitempty"`
+	// 目标 Secret 已存在且不受控制器管理时的处理策略，默认为 Overwrite
+	// +kubebuilder:default=Overwrite
+	// +optional
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
+	// 需要同步的数据键，未设置时同步全部数据
+	// +optional
+	Keys *KeyFilter `json:"keys,omitempty"`
+	// 键重命名规则，在 keys 筛选之后应用；被映射的源键不会以原名出现在目标中
+	// +optional
+	KeyMappings []KeyMapping `json:"keyMappings,omitempty"`
+	// 目标 Secret 的数据模板，渲染结果覆盖 keys 与 keyMappings 处理后的同名键
+	// +optional
+	Template *SecretTemplate `json:"template,omitempty"`
+	// 目标 Secret 的标签与注解策略
+	// +optional
+	TargetMetadata *TargetMetadata `json:"targetMetadata,omitempty"`
+}
+
+// TargetState 描述单个目标 Secret 的同步状态
+// +kubebuilder:validation:Enum=Synced;Failed;Skipped;Pruned;Conflicted;Drifted
+type TargetState string
+
+const (
+	// TargetStateSynced 目标 Secret 已与源保持一致
+	TargetStateSynced TargetState = "Synced"
+	// TargetStateFailed 同步到目标命名空间失败，原因见 message
+	TargetStateFailed TargetState = "Failed"
+	// TargetStateSkipped 目标命名空间被匹配到但未被同步，原因见 message
+	TargetStateSkipped TargetState = "Skipped"
+	// TargetStatePruned 目标 Secret 已不在目标集合中，已被清理
+	TargetStatePruned TargetState = "Pruned"
+	// TargetStateConflicted 目标命名空间中已存在不受管理的同名 Secret，按 Skip 策略未做修改
+	TargetStateConflicted TargetState = "Conflicted"
+	// TargetStateDrifted 目标 Secret 与源不一致，但 Audit 或 DryRun 模式下未做修改
+	TargetStateDrifted TargetState = "Drifted"
+)
+
+// TargetStatus 记录单个目标命名空间的同步结果
+type TargetStatus struct {
+	// 目标命名空间
+	Namespace string `json:"namespace"`
+	// 目标 Secret 名称
+	Name string `json:"name"`
+	// 同步状态
+	State TargetState `json:"state"`
+	// 最近一次错误或跳过的原因
+	// +optional
+	Message string `json:"message,omitempty"`
+	// 最近一次写入目标 Secret 的数据哈希（sha256）
+	// +optional
+	Hash string `json:"hash,omitempty"`
+	// 连续失败的次数，成功后清零
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+	// 下一次重试的时间，在此之前只要期望写入的数据不变就不会重试
+	// 失败但没有重试时间表示错误不可重试（如被 admission webhook 拒绝或超出配额），
+	// 只有源数据、spec 变化或 resync-at 注解才会触发重试
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+	// 最近一次失败时期望写入的数据哈希
+	// +optional
+	FailedHash string `json:"failedHash,omitempty"`
+	// 状态最近一次发生变化的时间
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
 }
 
 // SecretsyncStatus defines the observed state of Secretsync.
 type SecretsyncStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+	// 最近一次调和所处理的 generation
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// 标准状态条件：Ready、SourceAvailable、Degraded、Progressing
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// 已同步命名空间
 	SyncedNamespaces []string `json:"syncedNamespaces,omitempty"`
 	// 同步失败命名空间
 	FailedNamespaces []string `json:"failedNamespaces,omitempty"`
+	// 最近一次同步中被清理的命名空间
+	PrunedNamespaces []string `json:"prunedNamespaces,omitempty"`
+	// 每个目标命名空间的同步详情
+	// +optional
+	Targets []TargetStatus `json:"targets,omitempty"`
+	// DryRun 模式下计算出的变更计划
+	// +optional
+	Plan []PlannedChange `json:"plan,omitempty"`
+	// 被添加了 secretsync.example.com/watched 标签的源 Secret，格式为 <namespace>/<name>
+	// 源变更或对象删除后用于移除旧源上的标签
+	// +optional
+	WatchedSource string `json:"watchedSource,omitempty"`
+	// 当前源 Secret 完整数据与类型的哈希（sha256），不受 keys、keyMappings 与模板影响。
+	// 同步成功的目标 Secret 的 secretsync.example.com/source-hash 注解与之相同，
+	// 可用于判断轮换是否已推广到全部命名空间；各目标实际写入的数据哈希见 targets[].hash
+	// +optional
+	SourceHash string `json:"sourceHash,omitempty"`
+	// 最近一次已处理的 secretsync.stangj.com/resync-at 注解值
+	// +optional
+	LastHandledResyncAt string `json:"lastHandledResyncAt,omitempty"`
 	// 最后同步时间
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// 最后一次检查全部目标命名空间的完整调和时间
+	// 只重试失败目标的调和不会更新它，距离该时间满一个 syncInterval 后下一次调和总是完整调和
+	// +optional
+	LastFullSyncTime *metav1.Time `json:"lastFullSyncTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.mode`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Secretsync is the Schema for the secretsyncs API.
 type Secretsync struct {
@@ -63,6 +336,16 @@ type Secretsync struct {
 	Status SecretsyncStatus `json:"status,omitempty"`
 }
 
+// SyncSpec 返回同步配置，便于控制器统一处理 Secretsync 与 ClusterSecretsync
+func (s *Secretsync) SyncSpec() *SecretsyncSpec {
+	return &s.Spec
+}
+
+// SyncStatus 返回同步状态，便于控制器统一处理 Secretsync 与 ClusterSecretsync
+func (s *Secretsync) SyncStatus() *SecretsyncStatus {
+	return &s.Status
+}
+
 // +kubebuilder:object:root=true
 
 // SecretsyncList contains a list of Secretsync.
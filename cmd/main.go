@@ -21,16 +21,20 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -63,6 +67,10 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var excludedNamespaces string
+	var maxConcurrentReconciles, targetConcurrency int
+	var targetWriteQPS float64
+	var targetWriteBurst int
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -81,6 +89,16 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&excludedNamespaces, "excluded-namespaces", strings.Join(controller.DefaultExcludedNamespaces, ","),
+		"Comma-separated namespaces that are never selected as sync targets unless listed explicitly in targetNamespaces.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"Maximum number of Secretsync and ClusterSecretsync objects reconciled concurrently by each controller.")
+	flag.IntVar(&targetConcurrency, "target-concurrency", 10,
+		"Maximum number of target namespaces synced concurrently within a single reconcile.")
+	flag.Float64Var(&targetWriteQPS, "target-write-qps", 50,
+		"Maximum rate of target Secret creates and updates per second across all controllers. 0 disables the limit.")
+	flag.IntVar(&targetWriteBurst, "target-write-burst", 100,
+		"Maximum burst of target Secret creates and updates allowed by --target-write-qps.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -179,7 +197,14 @@ func main() {
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 scheme,
+		Scheme: scheme,
+		// Only cache Secrets the controller manages or reads as sources, instead of
+		// every Secret in the cluster. Other Secrets are read directly when needed.
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.Secret{}: {Label: controller.SecretCacheSelector()},
+			},
+		},
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -202,13 +227,35 @@ func main() {
 		os.Exit(1)
 	}
 
+	excluded := splitList(excludedNamespaces)
+	// Both controllers share one limiter so the flag caps target writes for the whole process.
+	writeLimiter := controller.NewWriteLimiter(targetWriteQPS, targetWriteBurst)
 	if err := (&controller.SecretsyncReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		ExcludedNamespaces:      excluded,
+		APIReader:               mgr.GetAPIReader(),
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		TargetConcurrency:       targetConcurrency,
+		WriteLimiter:            writeLimiter,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Secretsync")
 		os.Exit(1)
 	}
+	if err := (&controller.ClusterSecretsyncReconciler{
+		SecretsyncReconciler: controller.SecretsyncReconciler{
+			Client:                  mgr.GetClient(),
+			Scheme:                  mgr.GetScheme(),
+			ExcludedNamespaces:      excluded,
+			APIReader:               mgr.GetAPIReader(),
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			TargetConcurrency:       targetConcurrency,
+			WriteLimiter:            writeLimiter,
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterSecretsync")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -242,3 +289,14 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// splitList parses a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
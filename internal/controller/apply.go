@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fieldManager 是控制器以 server-side apply 写入目标 Secret 时使用的字段管理者
+const fieldManager = "secretsync-controller"
+
+// legacyFieldManagers 是改用 server-side apply 之前控制器以 Update 写入目标时的字段管理者，
+// 即 API server 根据 manager 二进制的 User-Agent 推断出的名称。
+// 受管理的目标第一次 apply 前会把它们的字段归并到 fieldManager，否则会与自己的旧记录冲突
+var legacyFieldManagers = sets.New("manager")
+
+// desiredTarget 构造用于 server-side apply 的目标 Secret，只包含控制器负责的字段
+// sourceHash 是源内容的哈希，与源的 resourceVersion 一起以注解写入目标
+// claim 为 false 时（Overwrite 策略下不受管理的 Secret）只写入数据、类型与这两个注解，
+// 不写入标签、targetMetadata 注解和所有者引用。
+// apply 的响应会解码回返回的对象，解码时向已有的 Data map 中写入键，
+// 因此 data 总是被复制，不能把源或其他命名空间共享的 map 交给写操作
+func desiredTarget(
+	src *corev1.Secret,
+	data map[string][]byte,
+	sourceHash, namespace, name string,
+	syncObj syncObject,
+	claim bool,
+) *corev1.Secret {
+	target := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: maps.Clone(data),
+		Type: src.Type,
+	}
+	if claim {
+		target.Labels = targetLabels(src, syncObj)
+		target.Annotations = targetAnnotations(src, syncObj)
+	}
+	if target.Annotations == nil {
+		target.Annotations = make(map[string]string, 2)
+	}
+	target.Annotations[sourceHashAnnotation] = sourceHash
+	target.Annotations[sourceResourceVersionAnnotation] = src.ResourceVersion
+	return target
+}
+
+// targetDiff 返回目标 Secret 与期望状态不一致的原因，一致时返回空字符串
+// 先比较源哈希注解，不一致时无需逐项比较数据；注解一致时仍需检查数据是否被直接修改过。
+// 只比较期望中的字段，以及控制器之前 apply 过、现在需要移除的键；其他管理者写入的键不影响结果。
+// 源的 resourceVersion 注解不参与比较，源的元数据变化不会导致重写目标
+func targetDiff(existing, desired *corev1.Secret) string {
+	if existing.Annotations[sourceHashAnnotation] != desired.Annotations[sourceHashAnnotation] {
+		return "Target Secret was synced from different source content"
+	}
+	if existing.Type != desired.Type || !containsData(existing.Data, desired.Data) ||
+		hasStaleKeys(appliedKeys(existing, "data"), desired.Data) {
+		return "Target Secret data or type differs from the source"
+	}
+	annotations := maps.Clone(desired.Annotations)
+	delete(annotations, sourceResourceVersionAnnotation)
+	if !hasEntries(existing.Labels, desired.Labels) || !hasEntries(existing.Annotations, annotations) ||
+		hasStaleKeys(appliedKeys(existing, "metadata", "labels"), desired.Labels) ||
+		hasStaleKeys(appliedKeys(existing, "metadata", "annotations"), desired.Annotations) {
+		return "Target Secret labels or annotations are outdated"
+	}
+	return ""
+}
+
+// containsData 判断 actual 是否包含 want 中的全部键值
+func containsData(actual, want map[string][]byte) bool {
+	for k, v := range want {
+		w, ok := actual[k]
+		if !ok || !bytes.Equal(v, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasStaleKeys 判断控制器 apply 过的键中是否有不再期望的
+func hasStaleKeys[V any](applied sets.Set[string], desired map[string]V) bool {
+	for k := range applied {
+		if _, ok := desired[k]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// appliedKeys 从 managedFields 中读取 fieldManager 通过 apply 拥有的、位于 path 下的键
+func appliedKeys(secret *corev1.Secret, path ...string) sets.Set[string] {
+	keys := sets.New[string]()
+	for _, entry := range secret.ManagedFields {
+		if entry.Manager == fieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			keys = keys.Union(fieldKeys(entry, path...))
+		}
+	}
+	return keys
+}
+
+// fieldKeys 返回 managedFields 条目中位于 path 下的键，path 为空时返回顶层字段
+func fieldKeys(entry metav1.ManagedFieldsEntry, path ...string) sets.Set[string] {
+	keys := sets.New[string]()
+	if entry.FieldsV1 == nil {
+		return keys
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+		return keys
+	}
+	for _, p := range path {
+		fields, _ = fields["f:"+p].(map[string]any)
+	}
+	for k := range fields {
+		if name, ok := strings.CutPrefix(k, "f:"); ok {
+			keys.Insert(name)
+		}
+	}
+	return keys
+}
+
+// appliedByOthers 判断是否有其他以 apply 写入的管理者持有 desired 中的字段
+// 这类管理者（如 GitOps 工具）声明了自己期望的值，控制器不覆盖它们，而是报告冲突；
+// 以 Update 写入的管理者（如 kubectl edit）只是手动修改，它们持有的字段由控制器夺回
+func appliedByOthers(existing, desired *corev1.Secret) bool {
+	for _, entry := range existing.ManagedFields {
+		if entry.Manager == fieldManager || entry.Operation != metav1.ManagedFieldsOperationApply {
+			continue
+		}
+		if fieldKeys(entry).Has("type") && desired.Type != "" ||
+			hasAnyKey(fieldKeys(entry, "data"), desired.Data) ||
+			hasAnyKey(fieldKeys(entry, "metadata", "labels"), desired.Labels) ||
+			hasAnyKey(fieldKeys(entry, "metadata", "annotations"), desired.Annotations) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnyKey 判断 keys 中是否有 desired 包含的键
+func hasAnyKey[V any](keys sets.Set[string], desired map[string]V) bool {
+	for k := range desired {
+		if keys.Has(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTarget 以 fieldManager 的身份 apply 目标 Secret
+// force 为 true 时夺取其他管理者持有的冲突字段，否则冲突以 409 Conflict 错误返回
+// existing 为 nil 表示目标尚不存在
+func (r *SecretsyncReconciler) applyTarget(ctx context.Context, existing, desired *corev1.Secret, force bool) error {
+	if existing != nil && isManaged(existing) {
+		patch, err := csaupgrade.UpgradeManagedFieldsPatch(existing, legacyFieldManagers, fieldManager)
+		if err != nil {
+			return fmt.Errorf("upgrade managed fields of Secret %s/%s: %w", existing.Namespace, existing.Name, err)
+		}
+		if patch != nil {
+			if err := r.waitForWrite(ctx); err != nil {
+				return err
+			}
+			if err := r.Patch(ctx, existing, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+				return fmt.Errorf("upgrade managed fields of Secret %s/%s: %w", existing.Namespace, existing.Name, err)
+			}
+		}
+	}
+
+	opts := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if force {
+		opts = append(opts, client.ForceOwnership)
+	}
+	if err := r.waitForWrite(ctx); err != nil {
+		return err
+	}
+	return r.Patch(ctx, desired, client.Apply, opts...)
+}
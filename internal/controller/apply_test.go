@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Server-side apply of targets", func() {
+	applied := func(fields string) []metav1.ManagedFieldsEntry {
+		return []metav1.ManagedFieldsEntry{
+			{
+				Manager:   fieldManager,
+				Operation: metav1.ManagedFieldsOperationApply,
+				FieldsV1:  &metav1.FieldsV1{Raw: []byte(fields)},
+			},
+			{
+				Manager:   "kubectl-edit",
+				Operation: metav1.ManagedFieldsOperationUpdate,
+				FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:foreign":{}}}`)},
+			},
+		}
+	}
+
+	It("should read the keys applied by the controller", func() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			ManagedFields: applied(`{"f:data":{".":{},"f:token":{}},"f:metadata":{"f:labels":{".":{},"f:team":{}}}}`),
+		}}
+		Expect(appliedKeys(secret, "data").UnsortedList()).To(ConsistOf("token"))
+		Expect(appliedKeys(secret, "metadata", "labels").UnsortedList()).To(ConsistOf("team"))
+		Expect(appliedKeys(secret, "metadata", "annotations").Len()).To(BeZero())
+	})
+
+	It("should ignore keys owned by other managers and detect stale applied keys", func() {
+		desired := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "a"}},
+			Data:       map[string][]byte{"token": []byte("t")},
+		}
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:        map[string]string{"team": "a", "extra": "x"},
+				ManagedFields: applied(`{"f:data":{"f:token":{}},"f:metadata":{"f:labels":{"f:team":{}}}}`),
+			},
+			Data: map[string][]byte{"token": []byte("t"), "foreign": []byte("f")},
+		}
+		Expect(targetDiff(existing, desired)).To(BeEmpty())
+
+		existing.Data["token"] = []byte("old")
+		Expect(targetDiff(existing, desired)).To(ContainSubstring("data"))
+
+		existing.Data["token"] = []byte("t")
+		desired.Labels = nil
+		Expect(targetDiff(existing, desired)).To(ContainSubstring("labels"))
+	})
+
+	It("should compare the source hash before the data", func() {
+		src := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "2"}}
+		data := map[string][]byte{"token": []byte("t")}
+		desired := desiredTarget(src, data, "h2", "team-a", "app", nil, false)
+		Expect(desired.Annotations).To(HaveKeyWithValue(sourceHashAnnotation, "h2"))
+		Expect(desired.Annotations).To(HaveKeyWithValue(sourceResourceVersionAnnotation, "2"))
+
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				sourceHashAnnotation:            "h1",
+				sourceResourceVersionAnnotation: "1",
+			}},
+			Data: map[string][]byte{"token": []byte("t")},
+		}
+		Expect(targetDiff(existing, desired)).To(ContainSubstring("different source content"))
+
+		By("ignoring a resourceVersion-only change of the source")
+		existing.Annotations[sourceHashAnnotation] = "h2"
+		Expect(targetDiff(existing, desired)).To(BeEmpty())
+	})
+
+	It("should not share the data map with the caller", func() {
+		data := map[string][]byte{"token": []byte("t")}
+		desired := desiredTarget(&corev1.Secret{}, data, "h", "team-a", "app", nil, false)
+		desired.Data["foreign"] = []byte("f")
+		Expect(data).NotTo(HaveKey("foreign"))
+	})
+
+	It("should only defer to other managers that applied the desired fields", func() {
+		desired := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "a"}},
+			Data:       map[string][]byte{"password": []byte("p"), "foreign": []byte("f")},
+		}
+		edited := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{ManagedFields: applied(`{"f:data":{"f:password":{}}}`)}}
+		Expect(appliedByOthers(edited, desired)).To(BeFalse())
+
+		gitops := edited.DeepCopy()
+		gitops.ManagedFields = append(gitops.ManagedFields, metav1.ManagedFieldsEntry{
+			Manager:   "gitops",
+			Operation: metav1.ManagedFieldsOperationApply,
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:password":{}}}`)},
+		})
+		Expect(appliedByOthers(gitops, desired)).To(BeTrue())
+
+		unrelated := edited.DeepCopy()
+		unrelated.ManagedFields = append(unrelated.ManagedFields, metav1.ManagedFieldsEntry{
+			Manager:   "gitops",
+			Operation: metav1.ManagedFieldsOperationApply,
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:other":{}},"f:metadata":{"f:labels":{"f:env":{}}}}`)},
+		})
+		Expect(appliedByOthers(unrelated, desired)).To(BeFalse())
+	})
+})
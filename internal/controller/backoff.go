@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	syncv1 "github.com/stangj/secretsync-controller/api/v1"
+)
+
+const (
+	// targetRetryBaseDelay 是目标第一次失败后的重试间隔，之后每次失败翻倍
+	targetRetryBaseDelay = 5 * time.Second
+	// targetRetryMaxDelay 是单个目标重试间隔的上限
+	targetRetryMaxDelay = 10 * time.Minute
+)
+
+// targetRetryDelay 返回连续失败 attempts 次后的重试间隔
+func targetRetryDelay(attempts int32) time.Duration {
+	delay := targetRetryBaseDelay
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= targetRetryMaxDelay {
+			return targetRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// isRetriable 判断写入目标失败的错误是否值得按退避重试
+// 被 admission webhook 或 ValidatingAdmissionPolicy 拒绝、超出 ResourceQuota 以及对象无效的错误
+// 在数据不变时重试也不会成功，需要等待源数据或 spec 变化
+func isRetriable(err error) bool {
+	if err == nil {
+		return true
+	}
+	msg := err.Error()
+	switch {
+	case errors.IsInvalid(err):
+		return false
+	case errors.IsForbidden(err) && strings.Contains(msg, "exceeded quota"):
+		return false
+	case strings.Contains(msg, "admission webhook") && strings.Contains(msg, "denied the request"):
+		return false
+	case strings.Contains(msg, "ValidatingAdmissionPolicy") && strings.Contains(msg, "denied request"):
+		return false
+	}
+	return true
+}
+
+// backingOff 判断失败的目标是否仍处于退避中
+// 期望写入的数据与上一次失败时不同时立即重试
+func backingOff(prev syncv1.TargetStatus, hash string, now time.Time) bool {
+	if prev.State != syncv1.TargetStateFailed || prev.FailedHash == "" || prev.FailedHash != hash {
+		return false
+	}
+	if prev.NextRetryTime == nil {
+		// 不可重试的错误
+		return true
+	}
+	return now.Before(prev.NextRetryTime.Time)
+}
+
+// retryingTargets 返回有重试计划的失败目标所在的命名空间，以及其中最早的重试时间
+func retryingTargets(targets []syncv1.TargetStatus) ([]string, time.Time) {
+	var namespaces []string
+	var earliest time.Time
+	for _, t := range targets {
+		if t.State != syncv1.TargetStateFailed || t.NextRetryTime == nil {
+			continue
+		}
+		namespaces = append(namespaces, t.Namespace)
+		if earliest.IsZero() || t.NextRetryTime.Time.Before(earliest) {
+			earliest = t.NextRetryTime.Time
+		}
+	}
+	return namespaces, earliest
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	syncv1 "github.com/stangj/secretsync-controller/api/v1"
+)
+
+var _ = Describe("Target retry backoff", func() {
+	It("should double the delay up to the limit", func() {
+		Expect(targetRetryDelay(1)).To(Equal(targetRetryBaseDelay))
+		Expect(targetRetryDelay(3)).To(Equal(4 * targetRetryBaseDelay))
+		Expect(targetRetryDelay(100)).To(Equal(targetRetryMaxDelay))
+	})
+
+	It("should not retry admission and quota rejections", func() {
+		gr := schema.GroupResource{Resource: "secrets"}
+		Expect(isRetriable(apierrors.NewServiceUnavailable("busy"))).To(BeTrue())
+		Expect(isRetriable(apierrors.NewForbidden(gr, "app", fmt.Errorf("exceeded quota: compute")))).To(BeFalse())
+		Expect(isRetriable(apierrors.NewForbidden(gr, "app",
+			fmt.Errorf(`admission webhook "deny.example.com" denied the request: no`)))).To(BeFalse())
+		Expect(isRetriable(apierrors.NewInvalid(schema.GroupKind{Kind: "Secret"}, "app", nil))).To(BeFalse())
+	})
+
+	It("should hold failed targets until the retry time or a data change", func() {
+		now := time.Now()
+		next := metav1.NewTime(now.Add(time.Minute))
+		prev := syncv1.TargetStatus{State: syncv1.TargetStateFailed, FailedHash: "h1", NextRetryTime: &next}
+		Expect(backingOff(prev, "h1", now)).To(BeTrue())
+		Expect(backingOff(prev, "h2", now)).To(BeFalse())
+		Expect(backingOff(prev, "h1", now.Add(2*time.Minute))).To(BeFalse())
+
+		prev.NextRetryTime = nil
+		Expect(backingOff(prev, "h1", now.Add(time.Hour))).To(BeTrue())
+
+		prev.State = syncv1.TargetStateSynced
+		Expect(backingOff(prev, "h1", now)).To(BeFalse())
+	})
+
+	It("should record consecutive failures with their retry time", func() {
+		now := metav1.Now()
+		targets := newTargetRecorder(nil, now)
+		targets.fail("team-a", "app", "busy", "h1", true)
+		first := targets.result()[0]
+		Expect(first.Attempts).To(BeEquivalentTo(1))
+		Expect(first.NextRetryTime.Time).To(Equal(now.Add(targetRetryBaseDelay)))
+
+		targets = newTargetRecorder([]syncv1.TargetStatus{first}, now)
+		targets.fail("team-a", "app", "denied", "h1", false)
+		second := targets.result()[0]
+		Expect(second.Attempts).To(BeEquivalentTo(2))
+		Expect(second.NextRetryTime).To(BeNil())
+		Expect(second.FailedHash).To(Equal("h1"))
+	})
+})
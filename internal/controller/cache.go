@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	syncv1 "github.com/stangj/secretsync-controller/api/v1"
+)
+
+const (
+	// watchedLabel 标记需要进入控制器缓存的 Secret：受管理的目标 Secret 以及被引用的源 Secret
+	// 管理器只缓存带有该标签的 Secret，避免把集群中所有凭据都保存在内存中
+	watchedLabel = "secretsync.example.com/watched"
+	// watchedValue 是 watchedLabel 的取值
+	watchedValue = "true"
+)
+
+// SecretCacheSelector 返回管理器缓存 Secret 时使用的标签选择器
+func SecretCacheSelector() labels.Selector {
+	return labels.SelectorFromSet(labels.Set{watchedLabel: watchedValue})
+}
+
+// getSecret 先从缓存读取 Secret，缓存中没有时再直接读取 API server
+// 尚未打上 watchedLabel 的源 Secret 与旧版本创建的目标 Secret 不在缓存中
+func (r *SecretsyncReconciler) getSecret(ctx context.Context, key types.NamespacedName, secret *corev1.Secret) error {
+	err := r.Get(ctx, key, secret)
+	if errors.IsNotFound(err) && r.APIReader != nil {
+		return r.APIReader.Get(ctx, key, secret)
+	}
+	return err
+}
+
+// ensureWatched 为源 Secret 添加 watchedLabel，使其进入缓存，后续变化能够立即触发调和
+func (r *SecretsyncReconciler) ensureWatched(ctx context.Context, secret *corev1.Secret) error {
+	if secret.Labels[watchedLabel] == watchedValue {
+		return nil
+	}
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Labels == nil {
+		secret.Labels = make(map[string]string)
+	}
+	secret.Labels[watchedLabel] = watchedValue
+	return r.Patch(ctx, secret, patch)
+}
+
+// releaseSource 在源 Secret 不再被任何同步对象引用时移除 ensureWatched 添加的 watchedLabel
+// ref 为 "<namespace>/<name>"，在同步对象删除或改用其他源 Secret 时调用。
+// 源 Secret 不属于控制器，不再被引用时不应继续留在缓存中；
+// 同时作为其他同步对象目标的源 Secret 仍需要该标签，保持不变
+func (r *SecretsyncReconciler) releaseSource(ctx context.Context, syncObj syncObject, ref string) error {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil
+	}
+	// 同时检查两种同步对象；源变化很少发生，直接列出后按源过滤即可
+	for _, list := range []client.ObjectList{&syncv1.SecretsyncList{}, &syncv1.ClusterSecretsyncList{}} {
+		if err := r.List(ctx, list); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			obj := item.(syncObject)
+			if obj.GetUID() != syncObj.GetUID() && obj.GetDeletionTimestamp().IsZero() &&
+				slices.Contains(indexSource(obj), ref) {
+				return nil
+			}
+		}
+	}
+
+	var source corev1.Secret
+	if err := r.getSecret(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &source); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if source.Labels[watchedLabel] != watchedValue || isManaged(&source) {
+		return nil
+	}
+	patch := client.MergeFrom(source.DeepCopy())
+	delete(source.Labels, watchedLabel)
+	return client.IgnoreNotFound(r.Patch(ctx, &source, patch))
+}
+
+// listNamespaces 以仅元数据的方式列出命名空间，与 Namespace 的元数据监视共用同一份缓存
+func (r *SecretsyncReconciler) listNamespaces(
+	ctx context.Context,
+	opts ...client.ListOption,
+) ([]metav1.PartialObjectMetadata, error) {
+	var list metav1.PartialObjectMetadataList
+	list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NamespaceList"))
+	if err := r.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
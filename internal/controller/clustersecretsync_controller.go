@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	syncv1 "github.com/stangj/secretsync-controller/api/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ClusterSecretsyncReconciler 结构体负责调和集群级的 ClusterSecretsync 对象
+// 同步逻辑与 SecretsyncReconciler 共用，只在对象类型和事件映射上有所区别
+type ClusterSecretsyncReconciler struct {
+	SecretsyncReconciler
+}
+
+// +kubebuilder:rbac:groups=sync.stangj.com,resources=clustersecretsyncs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=sync.stangj.com,resources=clustersecretsyncs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=sync.stangj.com,resources=clustersecretsyncs/finalizers,verbs=update
+
+// Reconcile 调和 ClusterSecretsync 对象，复用 Secretsync 的调和流程
+func (r *ClusterSecretsyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.reconcileObject(ctx, req, &syncv1.ClusterSecretsync{})
+}
+
+// listSyncObjects 列出集群中的 ClusterSecretsync 对象，opts 通常是字段索引条件
+func (r *ClusterSecretsyncReconciler) listSyncObjects(ctx context.Context, opts ...client.ListOption) ([]syncObject, error) {
+	var list syncv1.ClusterSecretsyncList
+	if err := r.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	items := make([]syncObject, 0, len(list.Items))
+	for i := range list.Items {
+		items = append(items, &list.Items[i])
+	}
+	return items, nil
+}
+
+// enqueueSecrets 确定源 Secret 或目标 Secret 变化时需要重新调和的 ClusterSecretsync
+func (r *ClusterSecretsyncReconciler) enqueueSecrets(ctx context.Context, obj client.Object) []reconcile.Request {
+	secret := obj.(*corev1.Secret)
+	items, err := r.listSyncObjects(ctx,
+		client.MatchingFields{sourceIndexField: sourceIndexValue(secret.Namespace, secret.Name)})
+	if err != nil {
+		r.Log.Error(err, "Failed to list ClusterSecretsync CRs")
+		return nil
+	}
+	return r.mapSecret(ctx, secret, items, "ClusterSecretsync")
+}
+
+// enqueueNamespaces 确定 Namespace 变化时需要重新调和的 ClusterSecretsync
+func (r *ClusterSecretsyncReconciler) enqueueNamespaces(ctx context.Context, obj client.Object) []reconcile.Request {
+	items, err := listNamespaceCandidates(ctx, obj.GetName(), r.listSyncObjects)
+	if err != nil {
+		r.Log.Error(err, "Failed to list ClusterSecretsync CRs")
+		return nil
+	}
+	requests := requestsForNamespace(obj, items, r.ExcludedNamespaces)
+	requests = append(requests, r.requestsForTargetsIn(ctx, obj.GetName(), "ClusterSecretsync")...)
+	r.scopes.markFull(requests)
+	return requests
+}
+
+// SetupWithManager 设置控制器与管理器的关联
+func (r *ClusterSecretsyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.scopes = newReconcileScopes()
+	if err := setupIndexes(mgr, &syncv1.ClusterSecretsync{}); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&syncv1.ClusterSecretsync{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueSecrets),
+			builder.WithPredicates(secretPredicate()),
+		).
+		WatchesMetadata(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueNamespaces),
+			builder.WithPredicates(namespacePredicate()),
+		).
+		WithOptions(r.controllerOptions()).
+		Complete(r)
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	syncv1 "github.com/stangj/secretsync-controller/api/v1"
+)
+
+var _ = Describe("ClusterSecretsync Controller", func() {
+	Context("When reconciling a resource", func() {
+		const (
+			resourceName = "cluster-resource"
+			sourceName   = "cluster-source"
+			targetName   = "cluster-target"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+		targetKey := types.NamespacedName{Name: targetName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating the source Secret and the ClusterSecretsync")
+			createSource(ctx, sourceName)
+			createSyncObject(ctx, &syncv1.ClusterSecretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+				Spec:       testSpec(sourceName, targetName),
+			})
+		})
+
+		It("should propagate the source and clean up on deletion", func() {
+			controllerReconciler := &ClusterSecretsyncReconciler{SecretsyncReconciler: *newTestReconciler()}
+
+			By("Reconciling the created resource")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			target := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			Expect(target.Labels).To(HaveKeyWithValue(ownerKindLabel, "ClusterSecretsync"))
+			Expect(target.Labels).NotTo(HaveKey(ownerNamespaceLabel))
+			Expect(target.Data).To(HaveKeyWithValue("password", []byte("s3cr3t")))
+
+			By("Stamping the target with the source content hash")
+			synced := &syncv1.ClusterSecretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, synced)).To(Succeed())
+			Expect(synced.Status.SourceHash).NotTo(BeEmpty())
+			Expect(target.Annotations).To(HaveKeyWithValue(sourceHashAnnotation, synced.Status.SourceHash))
+			Expect(target.Annotations).To(HaveKey(sourceResourceVersionAnnotation))
+
+			By("Hashing the source independently of key mappings")
+			sourceHash := hashSecretData(map[string][]byte{"password": []byte("s3cr3t")}, corev1.SecretTypeOpaque)
+			Expect(synced.Status.SourceHash).To(Equal(sourceHash))
+			synced.Spec.KeyMappings = []syncv1.KeyMapping{{From: "password", To: "db-password"}}
+			Expect(k8sClient.Update(ctx, synced)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, synced)).To(Succeed())
+			Expect(synced.Status.SourceHash).To(Equal(sourceHash))
+			Expect(synced.Status.Targets).To(HaveLen(1))
+			Expect(synced.Status.Targets[0].Hash).To(Equal(
+				hashSecretData(map[string][]byte{"db-password": []byte("s3cr3t")}, corev1.SecretTypeOpaque)))
+			Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			Expect(target.Annotations).To(HaveKeyWithValue(sourceHashAnnotation, sourceHash))
+
+			By("Deleting the resource and reconciling again")
+			resource := &syncv1.ClusterSecretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, targetKey, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When writing to several namespaces concurrently", func() {
+		const (
+			resourceName = "concurrent-resource"
+			sourceName   = "concurrent-source"
+			targetName   = "concurrent-target"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+		var namespaces []string
+
+		BeforeEach(func() {
+			By("creating the target namespaces, the source Secret and the ClusterSecretsync")
+			namespaces = createNamespaces(ctx, 8)
+			createSource(ctx, sourceName)
+			createSyncObject(ctx, &syncv1.ClusterSecretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+				Spec:       testSpec(sourceName, targetName, namespaces...),
+			})
+		})
+
+		It("should give every target its own copy of the source data", func() {
+			controllerReconciler := &ClusterSecretsyncReconciler{SecretsyncReconciler: *newTestReconciler()}
+			controllerReconciler.TargetConcurrency = 4
+
+			By("Reconciling with several workers")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Adding a foreign key to one target and reconciling again")
+			foreign := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: namespaces[0]}, foreign)).To(Succeed())
+			foreign.Data["foreign"] = []byte("only-here")
+			Expect(k8sClient.Update(ctx, foreign)).To(Succeed())
+			resource := &syncv1.ClusterSecretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Annotations = map[string]string{resyncAtAnnotation: "concurrent"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Targets).To(HaveLen(len(namespaces)))
+			for _, target := range resource.Status.Targets {
+				Expect(target.State).To(Equal(syncv1.TargetStateSynced), target.Namespace)
+			}
+			for _, ns := range namespaces[1:] {
+				target := &corev1.Secret{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: ns}, target)).To(Succeed())
+				Expect(target.Data).To(Equal(map[string][]byte{"password": []byte("s3cr3t")}), ns)
+			}
+		})
+	})
+
+	Context("When the target set shrinks", func() {
+		const (
+			resourceName = "prune-resource"
+			sourceName   = "prune-source"
+			targetName   = "prune-target"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+		var namespaces []string
+
+		BeforeEach(func() {
+			namespaces = createNamespaces(ctx, 2)
+			createSource(ctx, sourceName)
+		})
+
+		// reconcileWith 创建或更新 ClusterSecretsync 的 spec 后调和一次
+		reconcileWith := func(spec syncv1.SecretsyncSpec) *syncv1.ClusterSecretsync {
+			resource := &syncv1.ClusterSecretsync{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, resource); errors.IsNotFound(err) {
+				createSyncObject(ctx, &syncv1.ClusterSecretsync{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+					Spec:       spec,
+				})
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+				resource.Spec = spec
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			}
+			controllerReconciler := &ClusterSecretsyncReconciler{SecretsyncReconciler: *newTestReconciler()}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			return resource
+		}
+
+		It("should prune targets in namespaces that were dropped", func() {
+			reconcileWith(testSpec(sourceName, targetName, namespaces...))
+			for _, ns := range namespaces {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: ns}, &corev1.Secret{})).To(Succeed())
+			}
+
+			By("Dropping the second namespace")
+			resource := reconcileWith(testSpec(sourceName, targetName, namespaces[0]))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: namespaces[0]}, &corev1.Secret{})).To(Succeed())
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: namespaces[1]}, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(resource.Status.PrunedNamespaces).To(Equal([]string{namespaces[1]}))
+			Expect(resource.Status.SyncedNamespaces).To(Equal([]string{namespaces[0]}))
+		})
+
+		It("should prune the old Secret when the target name changes", func() {
+			reconcileWith(testSpec(sourceName, targetName, namespaces[0]))
+
+			By("Renaming the target Secret")
+			resource := reconcileWith(testSpec(sourceName, "prune-renamed", namespaces[0]))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "prune-renamed", Namespace: namespaces[0]}, &corev1.Secret{})).To(Succeed())
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: namespaces[0]}, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(resource.Status.PrunedNamespaces).To(Equal([]string{namespaces[0]}))
+		})
+
+		It("should prune as soon as a namespace leaves the selector", func() {
+			namespace := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: namespaces[0]}, namespace)).To(Succeed())
+			namespace.Labels = map[string]string{"prune-test": "enabled"}
+			Expect(k8sClient.Update(ctx, namespace)).To(Succeed())
+			spec := testSpec(sourceName, targetName)
+			spec.TargetNamespaces = nil
+			spec.TargetNamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"prune-test": "enabled"}}
+			reconcileWith(spec)
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: namespaces[0]}, &corev1.Secret{})).To(Succeed())
+
+			By("Removing the label from the namespace")
+			delete(namespace.Labels, "prune-test")
+			Expect(k8sClient.Update(ctx, namespace)).To(Succeed())
+			controllerReconciler := &ClusterSecretsyncReconciler{SecretsyncReconciler: *newTestReconciler()}
+			Expect(controllerReconciler.requestsForTargetsIn(ctx, namespace.Name, "ClusterSecretsync")).To(ConsistOf(
+				reconcile.Request{NamespacedName: typeNamespacedName}))
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: namespaces[0]}, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should keep dropped targets when prune is disabled", func() {
+			spec := testSpec(sourceName, targetName, namespaces...)
+			spec.Prune = ptr.To(false)
+			reconcileWith(spec)
+
+			By("Dropping the second namespace")
+			spec.TargetNamespaces = namespaces[:1]
+			resource := reconcileWith(spec)
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: namespaces[1]}, &corev1.Secret{})).To(Succeed())
+			Expect(resource.Status.PrunedNamespaces).To(BeEmpty())
+		})
+	})
+
+	Context("When the target set includes the source Secret", func() {
+		const (
+			resourceName = "self-resource"
+			sourceName   = "self-source"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+		sourceKey := types.NamespacedName{Name: sourceName, Namespace: testSourceNamespace}
+
+		It("should never write, prune or delete the source", func() {
+			other := createNamespaces(ctx, 1)[0]
+			createSource(ctx, sourceName)
+			spec := testSpec(sourceName, "", testSourceNamespace, other)
+			spec.ConflictPolicy = syncv1.ConflictPolicyAdopt
+			createSyncObject(ctx, &syncv1.ClusterSecretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+				Spec:       spec,
+			})
+			controllerReconciler := &ClusterSecretsyncReconciler{SecretsyncReconciler: *newTestReconciler()}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: sourceName, Namespace: other}, &corev1.Secret{})).To(Succeed())
+
+			source := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, sourceKey, source)).To(Succeed())
+			Expect(source.Labels).NotTo(HaveKey(managedByLabel))
+			Expect(source.Annotations).NotTo(HaveKey(sourceHashAnnotation))
+			Expect(source.OwnerReferences).To(BeEmpty())
+
+			resource := &syncv1.ClusterSecretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Targets).To(ContainElement(And(
+				HaveField("Namespace", testSourceNamespace),
+				HaveField("State", syncv1.TargetStateSkipped),
+			)))
+
+			By("Deleting the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, sourceKey, source)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,271 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	syncv1 "github.com/stangj/secretsync-controller/api/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// globToRegexp 将 glob 模式转换为完整匹配的正则表达式
+// * 匹配任意字符（包括 /），? 匹配单个字符，其余字符按字面匹配
+func globToRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// keyMatcher 是编译后的 KeyFilter
+type keyMatcher struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// newKeyMatcher 编译 KeyFilter，filter 为空时匹配所有键
+func newKeyMatcher(filter *syncv1.KeyFilter) *keyMatcher {
+	m := &keyMatcher{}
+	if filter == nil {
+		return m
+	}
+	for _, p := range filter.Include {
+		m.include = append(m.include, globToRegexp(p))
+	}
+	for _, p := range filter.Exclude {
+		m.exclude = append(m.exclude, globToRegexp(p))
+	}
+	return m
+}
+
+// matches 判断键是否被保留：未被排除，且 include 为空或命中任一 include 模式
+func (m *keyMatcher) matches(key string) bool {
+	for _, re := range m.exclude {
+		if re.MatchString(key) {
+			return false
+		}
+	}
+	if len(m.include) == 0 {
+		return true
+	}
+	for _, re := range m.include {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterData 返回按 spec.keys 筛选后的数据
+// 未设置筛选条件时直接返回源数据
+func filterData(data map[string][]byte, filter *syncv1.KeyFilter) map[string][]byte {
+	if filter == nil {
+		return data
+	}
+	m := newKeyMatcher(filter)
+	filtered := make(map[string][]byte, len(data))
+	for k, v := range data {
+		if m.matches(k) {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+// mapKeys 按 spec.keyMappings 重命名数据键
+// 被映射的源键以原名移除，映射到已存在的键时以映射值为准
+func mapKeys(data map[string][]byte, mappings []syncv1.KeyMapping) map[string][]byte {
+	if len(mappings) == 0 {
+		return data
+	}
+	renamed := make(map[string]struct{}, len(mappings))
+	for _, m := range mappings {
+		renamed[m.From] = struct{}{}
+	}
+
+	mapped := make(map[string][]byte, len(data)+len(mappings))
+	for k, v := range data {
+		if _, ok := renamed[k]; !ok {
+			mapped[k] = v
+		}
+	}
+	// 源键不存在（例如已被 keys 排除）时跳过该映射
+	for _, m := range mappings {
+		if v, ok := data[m.From]; ok {
+			mapped[m.To] = v
+		}
+	}
+	return mapped
+}
+
+// desiredData 计算写入目标 Secret 的数据：先按 keys 筛选，再按 keyMappings 重命名
+func desiredData(spec *syncv1.SecretsyncSpec, data map[string][]byte) map[string][]byte {
+	return mapKeys(filterData(data, spec.Keys), spec.KeyMappings)
+}
+
+// templateFuncs 是模板中可用的辅助函数，只包含无副作用的字符串处理
+var templateFuncs = template.FuncMap{
+	"b64enc": func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	},
+	"b64dec": func(s string) (string, error) {
+		b, err := base64.StdEncoding.DecodeString(s)
+		return string(b), err
+	},
+	"toJson": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+}
+
+// templateNamespace 是模板中 .Namespace 的内容
+type templateNamespace struct {
+	Name   string
+	Labels map[string]string
+}
+
+// templateContext 是模板渲染时的根对象
+type templateContext struct {
+	Data      map[string]string
+	Namespace templateNamespace
+}
+
+// dataRenderer 负责渲染 spec.template.data
+// 模板在每次调和时只解析一次，然后针对每个目标命名空间分别执行
+type dataRenderer struct {
+	keys      []string
+	templates map[string]*template.Template
+	source    map[string]string
+}
+
+// newDataRenderer 解析模板，未配置模板时返回 nil
+func newDataRenderer(tmpl *syncv1.SecretTemplate, source map[string][]byte) (*dataRenderer, error) {
+	if tmpl == nil || len(tmpl.Data) == 0 {
+		return nil, nil
+	}
+	d := &dataRenderer{
+		templates: make(map[string]*template.Template, len(tmpl.Data)),
+		source:    make(map[string]string, len(source)),
+	}
+	for k, v := range source {
+		d.source[k] = string(v)
+	}
+	for key, text := range tmpl.Data {
+		t, err := template.New(key).Option("missingkey=error").Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("parse template for key %q: %w", key, err)
+		}
+		d.keys = append(d.keys, key)
+		d.templates[key] = t
+	}
+	sort.Strings(d.keys)
+	return d, nil
+}
+
+// render 针对目标命名空间渲染模板，并叠加到 base 之上返回新的数据
+func (d *dataRenderer) render(base map[string][]byte, namespace string, labels map[string]string) (map[string][]byte, error) {
+	ctx := templateContext{
+		Data:      d.source,
+		Namespace: templateNamespace{Name: namespace, Labels: labels},
+	}
+	rendered := make(map[string][]byte, len(base)+len(d.keys))
+	for k, v := range base {
+		rendered[k] = v
+	}
+	for _, key := range d.keys {
+		var buf bytes.Buffer
+		if err := d.templates[key].Execute(&buf, ctx); err != nil {
+			return nil, fmt.Errorf("render template for key %q: %w", key, err)
+		}
+		rendered[key] = buf.Bytes()
+	}
+	return rendered, nil
+}
+
+// reservedAnnotations 是始终不会从源 Secret 复制到目标的系统注解
+// 复制 ServiceAccount 相关注解会让目标 Secret 被当作 token Secret 处理
+var reservedAnnotations = map[string]struct{}{
+	corev1.LastAppliedConfigAnnotation: {},
+	corev1.ServiceAccountNameKey:       {},
+	corev1.ServiceAccountUIDKey:        {},
+}
+
+// reservedMetadataPrefix 是控制器自身使用的标签与注解前缀，不从源复制
+const reservedMetadataPrefix = "secretsync.example.com/"
+
+// copyMetadata 按筛选规则从源元数据中复制标签或注解
+// filter 为 nil 时不复制；控制器自身的元数据与 reserved 中的键始终跳过
+func copyMetadata(source map[string]string, filter *syncv1.KeyFilter, reserved map[string]struct{}) map[string]string {
+	if filter == nil {
+		return nil
+	}
+	m := newKeyMatcher(filter)
+	copied := make(map[string]string)
+	for k, v := range source {
+		if _, ok := reserved[k]; ok || strings.HasPrefix(k, reservedMetadataPrefix) {
+			continue
+		}
+		if m.matches(k) {
+			copied[k] = v
+		}
+	}
+	return copied
+}
+
+// desiredMetadata 计算目标 Secret 的标签与注解（不含控制器的管理标签）
+func desiredMetadata(spec *syncv1.SecretsyncSpec, src *corev1.Secret) (labels, annotations map[string]string) {
+	labels = make(map[string]string)
+	annotations = make(map[string]string)
+	meta := spec.TargetMetadata
+	if meta == nil {
+		return labels, annotations
+	}
+	for k, v := range copyMetadata(src.Labels, meta.CopyLabels, nil) {
+		labels[k] = v
+	}
+	for k, v := range copyMetadata(src.Annotations, meta.CopyAnnotations, reservedAnnotations) {
+		annotations[k] = v
+	}
+	for k, v := range meta.Labels {
+		labels[k] = v
+	}
+	for k, v := range meta.Annotations {
+		annotations[k] = v
+	}
+	return labels, annotations
+}
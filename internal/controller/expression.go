@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// namespaceExpressionCostLimit 限制单次求值的开销，避免表达式拖慢调和
+const namespaceExpressionCostLimit = 1000000
+
+// namespaceExpressions 缓存编译后的 targetNamespaceExpression
+// 调和与 Namespace 事件映射共用，每个对象的每个 generation 只编译一次
+var namespaceExpressions = &expressionCache{entries: make(map[types.UID]*compiledExpression)}
+
+// compiledExpression 是某个对象在某个 generation 下的编译结果，编译错误同样被缓存
+type compiledExpression struct {
+	generation int64
+	expression string
+	program    cel.Program
+	err        error
+}
+
+// expressionCache 按对象 UID 缓存编译结果
+type expressionCache struct {
+	mu      sync.Mutex
+	entries map[types.UID]*compiledExpression
+}
+
+// get 返回对象当前 generation 的编译结果，未设置表达式时返回 nil
+func (c *expressionCache) get(syncObj syncObject) (cel.Program, error) {
+	expression := syncObj.SyncSpec().TargetNamespaceExpression
+	if expression == "" {
+		c.forget(syncObj.GetUID())
+		return nil, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[syncObj.GetUID()]
+	if !ok || entry.generation != syncObj.GetGeneration() || entry.expression != expression {
+		program, err := compileNamespaceExpression(expression)
+		entry = &compiledExpression{
+			generation: syncObj.GetGeneration(),
+			expression: expression,
+			program:    program,
+			err:        err,
+		}
+		c.entries[syncObj.GetUID()] = entry
+	}
+	return entry.program, entry.err
+}
+
+// forget 在对象删除或不再使用表达式时移除缓存
+func (c *expressionCache) forget(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, uid)
+}
+
+// compileNamespaceExpression 编译 CEL 表达式
+// 表达式中可使用 metadata.name、metadata.labels 与 metadata.annotations，结果必须为 bool
+func compileNamespaceExpression(expression string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Variable("metadata", cel.MapType(cel.StringType, cel.DynType)))
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid target namespace expression: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("target namespace expression must evaluate to bool, got %s", ast.OutputType())
+	}
+	return env.Program(ast, cel.CostLimit(namespaceExpressionCostLimit))
+}
+
+// matchesNamespaceExpression 对命名空间求值表达式
+// 求值出错（例如访问不存在的注解）或结果不是 bool 时视为不匹配
+func matchesNamespaceExpression(program cel.Program, ns metav1.Object) bool {
+	labels, annotations := ns.GetLabels(), ns.GetAnnotations()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	out, _, err := program.Eval(map[string]any{
+		"metadata": map[string]any{
+			"name":        ns.GetName(),
+			"labels":      labels,
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return false
+	}
+	matched, ok := out.Value().(bool)
+	return ok && matched
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Target fan-out", func() {
+	It("should visit every index exactly once", func() {
+		for _, workers := range []int{0, 1, 4, 100} {
+			visited := make([]int32, 25)
+			forEachLimit(len(visited), workers, func(i int) {
+				atomic.AddInt32(&visited[i], 1)
+			})
+			for i := range visited {
+				Expect(visited[i]).To(Equal(int32(1)), "workers=%d index=%d", workers, i)
+			}
+		}
+	})
+
+	It("should not run more than the configured number of workers", func() {
+		var running, peak int32
+		forEachLimit(50, 3, func(int) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			atomic.AddInt32(&running, -1)
+		})
+		Expect(peak).To(BeNumerically("<=", 3))
+	})
+})
@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	syncv1 "github.com/stangj/secretsync-controller/api/v1"
+)
+
+// testSourceNamespace 是 envtest 用例中源 Secret 所在的命名空间
+const testSourceNamespace = "default"
+
+// testSpec 返回把 default/sourceName 同步为 targetName 的 spec，未指定目标命名空间时同步到 default
+func testSpec(sourceName, targetName string, namespaces ...string) syncv1.SecretsyncSpec {
+	if len(namespaces) == 0 {
+		namespaces = []string{testSourceNamespace}
+	}
+	return syncv1.SecretsyncSpec{
+		SourceNamespace:  testSourceNamespace,
+		SourceSecretName: sourceName,
+		TargetSecretName: targetName,
+		TargetNamespaces: namespaces,
+	}
+}
+
+// createSource 在 default 命名空间中创建源 Secret，用例结束后删除
+func createSource(ctx context.Context, name string) *corev1.Secret {
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testSourceNamespace},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}
+	Expect(k8sClient.Create(ctx, source)).To(Succeed())
+	DeferCleanup(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, source))).To(Succeed())
+	})
+	return source
+}
+
+// createSyncObject 创建 Secretsync 或 ClusterSecretsync
+// 用例结束后移除 finalizer 并删除对象，再删除它同步出去的全部目标 Secret，
+// 这样用例中途失败也不会留下卡在 Terminating 的对象
+func createSyncObject(ctx context.Context, obj syncObject) {
+	Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+	DeferCleanup(func() {
+		current := obj.DeepCopyObject().(syncObject)
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), current)
+		if err == nil {
+			if controllerutil.RemoveFinalizer(current, secretsyncFinalizer) {
+				Expect(k8sClient.Update(ctx, current)).To(Succeed())
+			}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, current))).To(Succeed())
+		} else {
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		}
+
+		var targets corev1.SecretList
+		Expect(k8sClient.List(ctx, &targets, ownerLabels(obj))).To(Succeed())
+		for i := range targets.Items {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &targets.Items[i]))).To(Succeed())
+		}
+	})
+}
+
+// newTestReconciler 返回直接读写 envtest API server 的 SecretsyncReconciler
+func newTestReconciler() *SecretsyncReconciler {
+	return &SecretsyncReconciler{
+		Client: k8sClient,
+		Scheme: k8sClient.Scheme(),
+	}
+}
+
+// createNamespaces 创建 n 个名称唯一的命名空间
+// envtest 中没有命名空间控制器，删除的命名空间会一直处于 Terminating，因此不做清理
+func createNamespaces(ctx context.Context, n int) []string {
+	names := make([]string, 0, n)
+	for range n {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "secretsync-test-"}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		names = append(names, ns.Name)
+	}
+	return names
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	syncv1 "github.com/stangj/secretsync-controller/api/v1"
+)
+
+// DefaultExcludedNamespaces 是控制器默认排除的系统命名空间
+// 标签选择器即使匹配到它们也不会同步，除非在 targetNamespaces 中显式列出
+var DefaultExcludedNamespaces = []string{"kube-system", "kube-public", "kube-node-lease"}
+
+// isExcludedByName 仅根据命名空间名称判断是否被排除
+// spec.excludeNamespaces 始终生效；控制器级排除列表可被 targetNamespaces 显式覆盖
+func isExcludedByName(spec *syncv1.SecretsyncSpec, namespace string, defaults []string) bool {
+	if slices.Contains(spec.ExcludeNamespaces, namespace) {
+		return true
+	}
+	return slices.Contains(defaults, namespace) && !slices.Contains(spec.TargetNamespaces, namespace)
+}
+
+// compileNamespacePatterns 编译 targetNamespacePatterns
+// 以 / 包裹的模式按 RE2 正则处理并要求完整匹配，其余按 glob 处理
+func compileNamespacePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		if len(p) >= 2 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+			re, err := regexp.Compile("^(?:" + p[1:len(p)-1] + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid target namespace pattern %q: %w", p, err)
+			}
+			res = append(res, re)
+			continue
+		}
+		res = append(res, globToRegexp(p))
+	}
+	return res, nil
+}
+
+// matchesAnyPattern 判断命名空间名称是否命中任一模式
+func matchesAnyPattern(patterns []*regexp.Regexp, namespace string) bool {
+	for _, re := range patterns {
+		if re.MatchString(namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNamespaceSelection 校验目标命名空间的名称模式与 CEL 表达式
+// 错误会以 InvalidSpec 条件体现在状态中
+func validateNamespaceSelection(syncObj syncObject) error {
+	if _, err := compileNamespacePatterns(syncObj.SyncSpec().TargetNamespacePatterns); err != nil {
+		return err
+	}
+	_, err := namespaceExpressions.get(syncObj)
+	return err
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	syncv1 "github.com/stangj/secretsync-controller/api/v1"
+)
+
+var _ = Describe("Target namespace selection", func() {
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	syncWith := func(spec syncv1.SecretsyncSpec) []syncObject {
+		return []syncObject{&syncv1.ClusterSecretsync{ObjectMeta: metav1.ObjectMeta{Name: "all"}, Spec: spec}}
+	}
+
+	Context("requestsForNamespace", func() {
+		selectAll := syncv1.SecretsyncSpec{
+			TargetNamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: metav1.LabelSelectorOpExists}},
+			},
+		}
+
+		It("should skip namespaces excluded by the controller defaults", func() {
+			items := syncWith(selectAll)
+			Expect(requestsForNamespace(namespace("team-a", map[string]string{"env": "prod"}), items,
+				DefaultExcludedNamespaces)).To(HaveLen(1))
+			Expect(requestsForNamespace(namespace("kube-system", map[string]string{"env": "prod"}), items,
+				DefaultExcludedNamespaces)).To(BeEmpty())
+		})
+
+		It("should let explicit targets override the controller defaults", func() {
+			spec := selectAll
+			spec.TargetNamespaces = []string{"kube-system"}
+			Expect(requestsForNamespace(namespace("kube-system", nil), syncWith(spec),
+				DefaultExcludedNamespaces)).To(HaveLen(1))
+		})
+
+		It("should honor the spec exclusion list and reconcile namespaces that become excluded", func() {
+			spec := selectAll
+			spec.ExcludeNamespaces = []string{"team-a"}
+			spec.ExcludeNamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"sandbox": "true"}}
+			items := syncWith(spec)
+			Expect(requestsForNamespace(namespace("team-a", map[string]string{"env": "prod"}), items, nil)).To(BeEmpty())
+			// 新增排除标签后仍需调和，清理该命名空间中已有的目标
+			Expect(requestsForNamespace(namespace("team-b", map[string]string{"env": "dev", "sandbox": "true"}),
+				items, nil)).To(HaveLen(1))
+			Expect(requestsForNamespace(namespace("team-c", map[string]string{"env": "dev"}), items, nil)).To(HaveLen(1))
+		})
+	})
+
+	Context("compileNamespacePatterns", func() {
+		It("should match glob and RE2 patterns against the whole name", func() {
+			patterns, err := compileNamespacePatterns([]string{"team-*-prod", "/^svc-[0-9]+$/"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matchesAnyPattern(patterns, "team-a-prod")).To(BeTrue())
+			Expect(matchesAnyPattern(patterns, "team-a-prod-old")).To(BeFalse())
+			Expect(matchesAnyPattern(patterns, "svc-42")).To(BeTrue())
+			Expect(matchesAnyPattern(patterns, "svc-x")).To(BeFalse())
+		})
+
+		It("should reject invalid regular expressions", func() {
+			_, err := compileNamespacePatterns([]string{"/team-(/"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should enqueue newly created namespaces that match a pattern", func() {
+			items := syncWith(syncv1.SecretsyncSpec{TargetNamespacePatterns: []string{"team-*-prod"}})
+			Expect(requestsForNamespace(namespace("team-b-prod", nil), items, nil)).To(HaveLen(1))
+			Expect(requestsForNamespace(namespace("team-b-dev", nil), items, nil)).To(BeEmpty())
+		})
+	})
+
+	Context("targetNamespaceExpression", func() {
+		const expression = `'tier' in metadata.annotations && metadata.annotations.tier == 'gold' && ` +
+			`!metadata.name.startsWith('tmp-')`
+
+		It("should select namespaces by name and annotations", func() {
+			program, err := compileNamespaceExpression(expression)
+			Expect(err).NotTo(HaveOccurred())
+
+			gold := namespace("team-a", nil)
+			gold.Annotations = map[string]string{"tier": "gold"}
+			Expect(matchesNamespaceExpression(program, gold)).To(BeTrue())
+
+			tmp := namespace("tmp-a", nil)
+			tmp.Annotations = map[string]string{"tier": "gold"}
+			Expect(matchesNamespaceExpression(program, tmp)).To(BeFalse())
+			Expect(matchesNamespaceExpression(program, namespace("team-b", nil))).To(BeFalse())
+		})
+
+		It("should reject expressions that do not compile or return bool", func() {
+			_, err := compileNamespaceExpression(`metadata.name.startsWith(`)
+			Expect(err).To(HaveOccurred())
+			_, err = compileNamespaceExpression(`metadata.name + "x"`)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should enqueue namespaces matching the expression", func() {
+			items := syncWith(syncv1.SecretsyncSpec{TargetNamespaceExpression: `metadata.labels.env == 'prod'`})
+			Expect(requestsForNamespace(namespace("team-a", map[string]string{"env": "prod"}), items, nil)).To(HaveLen(1))
+			Expect(requestsForNamespace(namespace("team-b", nil), items, nil)).To(BeEmpty())
+		})
+	})
+})
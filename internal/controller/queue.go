@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// resyncPriority 是 RequeueAfter 触发的周期调和在队列中的优先级
+	// 事件触发的请求使用默认优先级 0，因此源 Secret 变化与目标偏差会先于周期调和处理
+	resyncPriority = handler.LowPriority
+
+	// resyncJitterFactor 是周期调和间隔的最大随机延长比例
+	resyncJitterFactor = 0.1
+)
+
+// controllerOptions 返回两个控制器共用的选项
+// 使用优先级队列，并降低周期调和的优先级
+func (r *SecretsyncReconciler) controllerOptions() controller.Options {
+	return controller.Options{
+		MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+		UsePriorityQueue:        ptr.To(true),
+		NewQueue:                newResyncQueue,
+	}
+}
+
+// resyncQueue 包装 controller-runtime 的优先级队列
+// 调和结果中的 RequeueAfter 会沿用触发本次调和的请求的优先级，
+// 这里把不经过限速器的延迟入队（即周期调和）改为 resyncPriority；
+// 失败重试仍走限速器并保留原有优先级。已在队列中的请求遇到事件时会被提升到事件的优先级
+type resyncQueue struct {
+	priorityqueue.PriorityQueue[reconcile.Request]
+}
+
+// newResyncQueue 创建 resyncQueue，签名与 controller.Options.NewQueue 一致
+func newResyncQueue(
+	name string,
+	rateLimiter workqueue.TypedRateLimiter[reconcile.Request],
+) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	return resyncQueue{priorityqueue.New(name, func(o *priorityqueue.Opts[reconcile.Request]) {
+		o.RateLimiter = rateLimiter
+	})}
+}
+
+// AddWithOpts 降低周期调和的优先级后入队
+func (q resyncQueue) AddWithOpts(o priorityqueue.AddOpts, items ...reconcile.Request) {
+	if o.After > 0 && !o.RateLimited {
+		o.Priority = resyncPriority
+	}
+	q.PriorityQueue.AddWithOpts(o, items...)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Reconcile queue", func() {
+	req := func(name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}}
+	}
+
+	It("should lower the priority of periodic resyncs only", func() {
+		q := newResyncQueue("test-resync-priority", nil).(resyncQueue)
+		defer q.ShutDown()
+
+		for _, tc := range []struct {
+			opts     priorityqueue.AddOpts
+			priority int
+		}{
+			{priorityqueue.AddOpts{After: time.Millisecond}, resyncPriority},
+			{priorityqueue.AddOpts{RateLimited: true, Priority: 5}, 5},
+			{priorityqueue.AddOpts{}, 0},
+		} {
+			q.AddWithOpts(tc.opts, req("app"))
+			item, priority, _ := q.GetWithPriority()
+			Expect(item).To(Equal(req("app")))
+			Expect(priority).To(Equal(tc.priority))
+			q.Done(item)
+		}
+	})
+
+	It("should hand out events before due resyncs", func() {
+		q := newResyncQueue("test-resync-order", nil).(resyncQueue)
+		defer q.ShutDown()
+
+		q.AddWithOpts(priorityqueue.AddOpts{After: time.Millisecond}, req("resync"))
+		Eventually(q.Len).Should(Equal(1))
+		q.AddWithOpts(priorityqueue.AddOpts{}, req("event"))
+
+		item, _, _ := q.GetWithPriority()
+		Expect(item).To(Equal(req("event")))
+		q.Done(item)
+	})
+
+	It("should let events overtake a pending resync", func() {
+		q := newResyncQueue("test-resync-overtake", nil).(resyncQueue)
+		defer q.ShutDown()
+
+		q.AddWithOpts(priorityqueue.AddOpts{After: time.Hour}, req("app"))
+		q.AddWithOpts(priorityqueue.AddOpts{}, req("app"))
+
+		item, priority, _ := q.GetWithPriority()
+		Expect(item).To(Equal(req("app")))
+		Expect(priority).To(Equal(0))
+		q.Done(item)
+	})
+})
@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// NewWriteLimiter 按每秒写入次数和突发上限创建目标写入限速器
+// qps 小于等于 0 时返回 nil，表示不限速
+func NewWriteLimiter(qps float64, burst int) *rate.Limiter {
+	if qps <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(qps), burst)
+}
+
+// waitForWrite 在写入目标 Secret 前等待限速器放行，并记录等待时间
+// 上下文取消时返回错误，当前目标记为失败并在下一次调和中重试
+func (r *SecretsyncReconciler) waitForWrite(ctx context.Context) error {
+	if r.WriteLimiter == nil {
+		return nil
+	}
+	start := time.Now()
+	err := r.WriteLimiter.Wait(ctx)
+	writeLimiterWaitSeconds.Observe(time.Since(start).Seconds())
+	if err != nil {
+		return fmt.Errorf("wait for write rate limiter: %w", err)
+	}
+	return nil
+}
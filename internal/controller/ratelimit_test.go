@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Target write rate limiter", func() {
+	It("should be disabled without a positive rate", func() {
+		Expect(NewWriteLimiter(0, 10)).To(BeNil())
+		r := &SecretsyncReconciler{}
+		Expect(r.waitForWrite(context.Background())).To(Succeed())
+	})
+
+	It("should allow the burst and then block until the context ends", func() {
+		r := &SecretsyncReconciler{WriteLimiter: NewWriteLimiter(0.001, 2)}
+		Expect(r.waitForWrite(context.Background())).To(Succeed())
+		Expect(r.waitForWrite(context.Background())).To(Succeed())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(r.waitForWrite(ctx)).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileScopes 记录事件映射时得知的调和范围
+// 只由目标 Secret 事件触发的调和可以只处理对应的命名空间；
+// 源 Secret 与 Namespace 事件需要完整调和。同一个对象的请求在队列中会被合并，
+// 因此这里按对象累积，调和开始时一次性取出
+type reconcileScopes struct {
+	mu    sync.Mutex
+	dirty map[types.NamespacedName]map[string]struct{}
+	full  map[types.NamespacedName]struct{}
+}
+
+// newReconcileScopes 创建空的 reconcileScopes
+func newReconcileScopes() *reconcileScopes {
+	return &reconcileScopes{
+		dirty: make(map[types.NamespacedName]map[string]struct{}),
+		full:  make(map[types.NamespacedName]struct{}),
+	}
+}
+
+// markNamespace 记录某个目标命名空间需要重新检查
+func (s *reconcileScopes) markNamespace(key types.NamespacedName, namespace string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dirty[key] == nil {
+		s.dirty[key] = make(map[string]struct{})
+	}
+	s.dirty[key][namespace] = struct{}{}
+}
+
+// markFull 记录对象需要完整调和
+func (s *reconcileScopes) markFull(requests []reconcile.Request) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, req := range requests {
+		s.full[req.NamespacedName] = struct{}{}
+	}
+}
+
+// take 取出并清空对象的调和范围
+// 返回 nil 表示需要完整调和：没有记录到目标事件，或期间出现过需要完整调和的事件
+func (s *reconcileScopes) take(key types.NamespacedName) map[string]struct{} {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dirty := s.dirty[key]
+	_, full := s.full[key]
+	delete(s.dirty, key)
+	delete(s.full, key)
+	if full {
+		return nil
+	}
+	return dirty
+}
+
+// requestForTarget 根据目标 Secret 上的所有者标签找到管理它的同步对象
+// kind 为 Secretsync 或 ClusterSecretsync，只映射到对应类型的对象
+func requestForTarget(secret *corev1.Secret, kind string) (reconcile.Request, bool) {
+	if !isManaged(secret) || secret.Labels[ownerKindLabel] != kind || secret.Labels[ownerNameLabel] == "" {
+		return reconcile.Request{}, false
+	}
+	return reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: secret.Labels[ownerNamespaceLabel],
+		Name:      secret.Labels[ownerNameLabel],
+	}}, true
+}
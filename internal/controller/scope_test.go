@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Target Secret events", func() {
+	key := types.NamespacedName{Namespace: "default", Name: "app"}
+
+	It("should map managed targets back to their owner", func() {
+		target := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:      "copy",
+			Namespace: "team-a",
+			Labels: map[string]string{
+				managedByLabel:      managedByValue,
+				ownerKindLabel:      "Secretsync",
+				ownerNamespaceLabel: "default",
+				ownerNameLabel:      "app",
+			},
+		}}
+		req, ok := requestForTarget(target, "Secretsync")
+		Expect(ok).To(BeTrue())
+		Expect(req.NamespacedName).To(Equal(key))
+
+		_, ok = requestForTarget(target, "ClusterSecretsync")
+		Expect(ok).To(BeFalse())
+		_, ok = requestForTarget(&corev1.Secret{}, "Secretsync")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should scope reconciles to target namespaces until a full reconcile is needed", func() {
+		scopes := newReconcileScopes()
+		scopes.markNamespace(key, "team-a")
+		scopes.markNamespace(key, "team-b")
+		Expect(scopes.take(key)).To(HaveLen(2))
+		Expect(scopes.take(key)).To(BeNil())
+
+		scopes.markNamespace(key, "team-a")
+		scopes.markFull([]reconcile.Request{{NamespacedName: key}})
+		Expect(scopes.take(key)).To(BeNil())
+
+		var disabled *reconcileScopes
+		disabled.markNamespace(key, "team-a")
+		Expect(disabled.take(key)).To(BeNil())
+	})
+})
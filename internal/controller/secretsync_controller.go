@@ -17,18 +17,27 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"reflect"
+	"maps"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -45,14 +54,64 @@ type SecretsyncReconciler struct {
 	client.Client                 // Kubernetes API 客户端接口
 	Scheme        *runtime.Scheme // 用于序列化/反序列化对象以及设置所有者引用
 	Log           logr.Logger     // 结构化日志接口
+	// 控制器级排除的命名空间，通常为 DefaultExcludedNamespaces
+	ExcludedNamespaces []string
+	// 绕过缓存直接读取 API server，用于读取不在缓存中的 Secret；为 nil 时只使用 Client
+	APIReader client.Reader
+
+	// 单次调和中并发同步目标命名空间的 worker 数量，小于等于 1 时逐个同步
+	TargetConcurrency int
+	// 控制器同时处理的调和请求数量，为 0 时使用 controller-runtime 的默认值 1
+	MaxConcurrentReconciles int
+	// 所有目标 Secret 的 Create 与 Update 共用的令牌桶限速器，为 nil 时不限速
+	// Secretsync 与 ClusterSecretsync 控制器应共享同一个实例
+	WriteLimiter *rate.Limiter
+
+	// 由事件映射记录的调和范围，在 SetupWithManager 中创建；为 nil 时总是完整调和
+	scopes *reconcileScopes
+}
+
+// syncObject 是 Secretsync 与 ClusterSecretsync 的公共抽象
+// 两者共用同一套 spec 与 status，调和逻辑只依赖这个接口
+type syncObject interface {
+	client.Object
+	SyncSpec() *syncv1.SecretsyncSpec
+	SyncStatus() *syncv1.SecretsyncStatus
 }
 
 // 以下是控制器所需的 RBAC 权限注解
-// +kubebuilder:rbac:groups=sync.example.com,resources=secretsyncs,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=sync.example.com,resources=secretsyncs/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups="",resources=namespaces,verbs=list;watch
+// +kubebuilder:rbac:groups=sync.stangj.com,resources=secretsyncs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=sync.stangj.com,resources=secretsyncs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=sync.stangj.com,resources=secretsyncs/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 
+const (
+	// secretsyncFinalizer 保证 Secretsync 删除前先处理好跨命名空间的目标 Secret
+	// 跨命名空间的所有者引用不会被垃圾回收处理，只能由控制器自行清理
+	secretsyncFinalizer = "sync.stangj.com/finalizer"
+
+	// resyncAtAnnotation 的取值变化时，控制器会重写全部目标 Secret，即使它们看起来没有变化
+	// 已处理的取值记录在 status.lastHandledResyncAt 中
+	resyncAtAnnotation = "secretsync.stangj.com/resync-at"
+
+	// 目标 Secret 上的管理标签，用于识别由控制器创建的 Secret 及其来源
+	managedByLabel       = "secretsync.example.com/managed-by"
+	sourceNamespaceLabel = "secretsync.example.com/source-namespace"
+	sourceNameLabel      = "secretsync.example.com/source-name"
+	ownerKindLabel       = "secretsync.example.com/owner-kind"
+	ownerNamespaceLabel  = "secretsync.example.com/owner-namespace"
+	ownerNameLabel       = "secretsync.example.com/owner-name"
+
+	// 目标 Secret 上记录来源版本的注解，使用方可以据此判断是否需要重新加载
+	// source-hash 与 status.sourceHash 一致即表示该目标已同步到当前的源内容
+	sourceHashAnnotation            = "secretsync.example.com/source-hash"
+	sourceResourceVersionAnnotation = "secretsync.example.com/source-resource-version"
+
+	// managedByValue 是 managed-by 标签的取值
+	managedByValue = "secretsync-controller"
+)
+
 // 定义 Prometheus 指标变量，用于监控控制器性能和状态
 var (
 	// syncTotalCounter 记录同步操作的总次数及结果
@@ -72,6 +131,24 @@ var (
 		},
 	)
 
+	// driftedTargetsGauge 记录 Audit 与 DryRun 模式下与源不一致的目标数量
+	driftedTargetsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "secretsync_drifted_targets",
+			Help: "Number of target Secrets that differ from the source in Audit or DryRun mode",
+		},
+		[]string{"kind", "namespace", "name", "mode"},
+	)
+
+	// writeLimiterWaitSeconds 记录目标 Secret 写入在全局限速器上等待的时间
+	writeLimiterWaitSeconds = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "secretsync_write_limiter_wait_seconds",
+			Help:    "Time target Secret writes spent waiting for the write rate limiter",
+			Buckets: []float64{0.001, 0.01, 0.05, 0.1, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+	)
+
 	// lastSuccessTimeGauge 记录最后一次成功同步的时间戳
 	lastSuccessTimeGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -83,20 +160,28 @@ var (
 
 // init 函数在包加载时执行，注册 Prometheus 指标
 func init() {
-	prometheus.MustRegister(syncTotalCounter, syncLatencySeconds, lastSuccessTimeGauge)
+	prometheus.MustRegister(syncTotalCounter, syncLatencySeconds, lastSuccessTimeGauge, driftedTargetsGauge,
+		writeLimiterWaitSeconds)
 }
 
 // Reconcile 是控制器的核心方法，实现了 controller-runtime 的 Reconciler 接口
 // 当 Secretsync 资源或相关资源发生变化时被调用
 func (r *SecretsyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.reconcileObject(ctx, req, &syncv1.Secretsync{})
+}
+
+// reconcileObject 是 Secretsync 与 ClusterSecretsync 共用的调和流程
+// syncObj 为待填充的空对象，决定了从 API 中获取的资源类型
+func (r *SecretsyncReconciler) reconcileObject(ctx context.Context, req ctrl.Request, syncObj syncObject) (ctrl.Result, error) {
 	// 记录开始时间，用于计算延迟
 	start := time.Now()
 	// 创建带有请求信息的日志记录器
 	log := r.Log.WithValues("secretsync", req.NamespacedName)
+	// 取出事件映射记录的调和范围，仅由目标 Secret 事件触发时只检查对应的命名空间
+	scope := r.scopes.take(req.NamespacedName)
 
 	// 获取 SecretSync 自定义资源对象
-	var syncObj syncv1.Secretsync
-	if err := r.Get(ctx, req.NamespacedName, &syncObj); err != nil {
+	if err := r.Get(ctx, req.NamespacedName, syncObj); err != nil {
 		if errors.IsNotFound(err) {
 			// 如果对象不存在，可能是已被删除，记录日志并退出
 			log.Info("SecretSync CR not found, skip reconciliation")
@@ -108,108 +193,314 @@ func (r *SecretsyncReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		syncTotalCounter.WithLabelValues("failure").Inc()
 		return ctrl.Result{}, err
 	}
+	spec := syncObj.SyncSpec()
+	status := syncObj.SyncStatus()
+
+	// 对象正在被删除，按删除策略处理目标 Secret 后移除 finalizer
+	if !syncObj.GetDeletionTimestamp().IsZero() {
+		if err := r.finalize(ctx, syncObj); err != nil {
+			log.Error(err, "Failed to finalize SecretSync CR")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// 暂停时不写入任何目标 Secret，也不再周期性重新入队，只报告 Suspended 条件
+	if spec.Suspend {
+		log.Info("SecretSync is suspended, skipping reconciliation")
+		markSuspended(syncObj)
+		r.updateStatus(ctx, syncObj)
+		return ctrl.Result{}, nil
+	}
+
+	// 确保 finalizer 存在，删除时才有机会清理目标 Secret
+	if !controllerutil.ContainsFinalizer(syncObj, secretsyncFinalizer) {
+		controllerutil.AddFinalizer(syncObj, secretsyncFinalizer)
+		if err := r.Update(ctx, syncObj); err != nil {
+			log.Error(err, "Failed to add finalizer to SecretSync CR")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// 恢复同步后移除 Suspended 条件，需在写入 finalizer 之后进行，否则会被服务端返回的状态覆盖
+	clearSuspended(syncObj)
+
+	// spec 发生变化时先标记 Progressing，便于 kubectl wait 等工具感知调和进度
+	if status.ObservedGeneration != syncObj.GetGeneration() {
+		markProgressing(syncObj)
+		if err := r.Status().Update(ctx, syncObj); err != nil {
+			log.Error(err, "Failed to update SecretSync status")
+			return ctrl.Result{}, err
+		}
+	}
 
 	// 验证必要的 spec 字段是否存在
-	if syncObj.Spec.SourceNamespace == "" || syncObj.Spec.SourceSecretName == "" {
-		log.Error(nil, "Invalid spec: SourceNamespace or SourceSecretName missing", "spec", syncObj.Spec)
+	if spec.SourceNamespace == "" || spec.SourceSecretName == "" {
+		log.Error(nil, "Invalid spec: SourceNamespace or SourceSecretName missing", "spec", *spec)
+		markFailed(syncObj, syncv1.ReasonInvalidSpec, "sourceNamespace and sourceSecretName are required")
 		// 更新最后同步时间，即使同步失败
-		syncObj.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
-		_ = r.Status().Update(ctx, &syncObj)
+		status.LastSyncTime = &metav1.Time{Time: time.Now()}
+		r.updateStatus(ctx, syncObj)
+		syncTotalCounter.WithLabelValues("failure").Inc()
+		return ctrl.Result{}, nil
+	}
+	err := validateNamespacedSpec(syncObj)
+	if err == nil {
+		err = validateNamespaceSelection(syncObj)
+	}
+	if err != nil {
+		log.Error(err, "Invalid spec: source or target namespace selection")
+		markFailed(syncObj, syncv1.ReasonInvalidSpec, err.Error())
+		status.LastSyncTime = &metav1.Time{Time: time.Now()}
+		r.updateStatus(ctx, syncObj)
 		syncTotalCounter.WithLabelValues("failure").Inc()
 		return ctrl.Result{}, nil
 	}
 
 	// 获取源 Secret 对象
 	srcNamespaceName := types.NamespacedName{
-		Namespace: syncObj.Spec.SourceNamespace,
-		Name:      syncObj.Spec.SourceSecretName,
+		Namespace: spec.SourceNamespace,
+		Name:      spec.SourceSecretName,
 	}
 	var srcSecret corev1.Secret
-	if err := r.Get(ctx, srcNamespaceName, &srcSecret); err != nil {
+	if err := r.getSecret(ctx, srcNamespaceName, &srcSecret); err != nil {
 		// 源 Secret 可能不存在或获取出错
 		log.Error(err, "Failed to get source Secret", "secret", srcNamespaceName)
+		reason := syncv1.ReasonSourceError
+		if errors.IsNotFound(err) {
+			reason = syncv1.ReasonSourceNotFound
+		}
+		message := fmt.Sprintf("Failed to get source Secret %s: %v", srcNamespaceName, err)
+		setCondition(syncObj, syncv1.ConditionSourceAvailable, metav1.ConditionFalse, reason, message)
+		markFailed(syncObj, reason, message)
+		r.updateStatus(ctx, syncObj)
 		syncTotalCounter.WithLabelValues("failure").Inc()
 		return ctrl.Result{}, err
 	}
+	setCondition(syncObj, syncv1.ConditionSourceAvailable, metav1.ConditionTrue,
+		syncv1.ReasonSourceFound, fmt.Sprintf("Source Secret %s is available", srcNamespaceName))
 
-	// 更新为同时使用标签选择器和显式指定的命名空间列表
-	namespaces, err := r.getMatchingNamespaces(
-		ctx,
-		syncObj.Spec.TargetNamespaceSelector,
-		syncObj.Spec.TargetNamespaces, // 新增：传入显式指定的命名空间列表
-	)
+	// 同时使用标签选择器和显式指定的命名空间列表，并应用排除规则
+	namespaces, err := r.getMatchingNamespaces(ctx, syncObj)
 	if err != nil {
 		log.Error(err, "Failed to list matched namespaces")
+		markFailed(syncObj, syncv1.ReasonNamespaceListFailed, fmt.Sprintf("Failed to list target namespaces: %v", err))
+		r.updateStatus(ctx, syncObj)
 		syncTotalCounter.WithLabelValues("failure").Inc()
 		return ctrl.Result{}, err
 	}
 
+	// 命名空间级的 Secretsync 只能同步到自身所在的命名空间
+	namespaces, skipped := restrictNamespaces(syncObj, namespaces)
+	if len(skipped) > 0 {
+		log.Info("Skipping target namespaces outside of the Secretsync namespace", "namespaces", skipped)
+	}
+
 	// 确定目标 Secret 的名称
 	// 如果没有指定，则使用源 Secret 的名称
-	targetSecretName := syncObj.Spec.TargetSecretName
+	targetSecretName := spec.TargetSecretName
 	if targetSecretName == "" {
 		targetSecretName = srcSecret.Name
 	}
 
-	// 用于记录同步结果的数组
-	var synced []string // 成功同步的命名空间
-	var failed []string // 失败的命名空间
+	// Audit 与 DryRun 模式复用同样的比较逻辑，只是不写入，而是记录计划
+	writeTargets := spec.Mode != syncv1.SyncModeAudit && spec.Mode != syncv1.SyncModeDryRun
+	// 标记源 Secret 使其进入缓存；失败时仍可通过 API server 读取，只是源的变化要等到下一次周期调和
+	// Audit 与 DryRun 模式不写入任何对象，源 Secret 也不例外
+	// 改用其他源 Secret 后释放旧源，释放失败时保留记录，下一次调和再试
+	if writeTargets {
+		if err := r.ensureWatched(ctx, &srcSecret); err != nil {
+			log.Error(err, "Failed to label source Secret for watching", "secret", srcNamespaceName)
+		}
+		watched := sourceIndexValue(srcSecret.Namespace, srcSecret.Name)
+		if prev := status.WatchedSource; prev != "" && prev != watched {
+			if err := r.releaseSource(ctx, syncObj, prev); err != nil {
+				log.Error(err, "Failed to release previous source Secret", "secret", prev)
+				watched = prev
+			}
+		}
+		status.WatchedSource = watched
+	}
 
-	// 检查目标 Secret 是否已变更或删除
-	for _, ns := range namespaces {
-		needSync := false
+	// resync-at 注解发生变化时强制重写全部目标
+	resyncAt := syncObj.GetAnnotations()[resyncAtAnnotation]
+	forceResync := writeTargets && resyncAt != "" && resyncAt != status.LastHandledResyncAt
+	if forceResync {
+		log.Info("Forced resync requested", "resyncAt", resyncAt)
+	}
+	// 确定周期调和的间隔时间
+	// 使用用户指定的 SyncInterval 或默认值 180 秒
+	syncInterval := 180 * time.Second
+	if spec.SyncInterval > 0 {
+		syncInterval = time.Duration(spec.SyncInterval) * time.Second
+	}
+	// 距离上一次完整调和已满一个周期时必须完整调和，
+	// 否则持续失败的目标触发的重试会一直限定范围，健康的目标不再被检查，清理也不会执行
+	fullSyncDue := status.LastFullSyncTime == nil || time.Since(status.LastFullSyncTime.Time) >= syncInterval
+	// spec 变化、强制重写以及 Audit/DryRun 模式都需要检查全部目标，并忽略失败目标的退避
+	scopeable := writeTargets && !forceResync && !fullSyncDue && status.ObservedGeneration == syncObj.GetGeneration()
+	if !scopeable {
+		scope = nil
+	}
+	if scope != nil {
+		log.Info("Reconciling only target namespaces with Secret events", "namespaces", len(scope))
+	}
 
-		// 检查目标 Secret 是否存在
-		var targetSecret corev1.Secret
-		targetKey := types.NamespacedName{Namespace: ns, Name: targetSecretName}
-		err := r.Get(ctx, targetKey, &targetSecret)
+	// 用于记录同步结果的数组
+	var synced []string             // 成功同步的命名空间
+	var failed []string             // 失败的命名空间
+	var conflicted []string         // 存在不受管理的同名 Secret 而被跳过的命名空间
+	var plan []syncv1.PlannedChange // 未写入的变更（仅 Audit 与 DryRun 模式）
+	now := metav1.Now()
+	targets := newTargetRecorder(status.Targets, now)
+	for _, ns := range skipped {
+		targets.record(ns, targetSecretName, syncv1.TargetStateSkipped,
+			"Namespaced Secretsync can only sync into its own namespace", "")
+	}
+	// 按 spec.keys 筛选并按 spec.keyMappings 重命名，比较与写入都基于转换后的数据，
+	// 避免目标与完整的源数据反复比较而来回更新
+	data := desiredData(spec, srcSecret.Data)
+	// 未使用模板时所有目标写入相同的数据，哈希只需计算一次
+	dataHash := hashSecretData(data, srcSecret.Type)
+	// 解析数据模板，解析失败时所有目标都记录为失败，但不中断调和
+	renderer, tmplErr := newDataRenderer(spec.Template, srcSecret.Data)
+	if tmplErr != nil {
+		log.Error(tmplErr, "Failed to parse data template")
+	}
+	// 源内容哈希写入目标注解与 status.sourceHash，总是按完整的源数据与类型计算，与 keys、keyMappings
+	// 和模板无关；每个目标实际写入的数据哈希（dataHash 或渲染后的哈希）只记录在 status.targets 中
+	sourceHash := hashSecretData(srcSecret.Data, srcSecret.Type)
 
-		if err != nil {
-			if errors.IsNotFound(err) {
-				// 目标 Secret 不存在，需要同步
-				log.Info("Target Secret not found, will sync", "namespace", ns, "name", targetSecretName)
-				needSync = true
-			} else {
-				// 获取目标 Secret 出错
-				log.Error(err, "Failed to get target Secret", "namespace", ns, "name", targetSecretName)
-				failed = append(failed, ns)
+	// 限定范围时沿用其余目标上一次的结果，只检查需要处理的命名空间
+	results := make([]targetResult, len(namespaces))
+	var pending []int
+	for i, ns := range namespaces {
+		if _, dirty := scope[ns]; scope != nil && !dirty {
+			if prev, ok := targets.carry(ns, targetSecretName); ok {
+				results[i] = targetResult{state: prev.State, carried: true}
 				continue
 			}
-		} else {
-			// 目标 Secret 存在，检查数据是否一致
-			if !reflect.DeepEqual(targetSecret.Data, srcSecret.Data) || targetSecret.Type != srcSecret.Type {
-				log.Info("Target Secret data or type changed, will sync", "namespace", ns, "name", targetSecretName)
-				needSync = true
-			}
 		}
+		pending = append(pending, i)
+	}
 
-		// 如果需要同步，执行同步操作
-		if needSync {
-			if err := r.syncSecret(ctx, &srcSecret, ns, targetSecretName, &syncObj); err != nil {
-				// 同步到当前命名空间失败，记录错误
-				log.Error(err, "Failed to sync secret to namespace", "namespace", ns)
-				failed = append(failed, ns)
-			} else {
-				// 同步成功，记录成功的命名空间
-				synced = append(synced, ns)
-			}
-		} else {
-			// 不需要同步，记录为成功
+	// 检查目标 Secret 是否已变更或删除，各命名空间由有限数量的 worker 并发处理
+	task := &targetTask{
+		log:          log,
+		source:       &srcSecret,
+		syncObj:      syncObj,
+		name:         targetSecretName,
+		data:         data,
+		dataHash:     dataHash,
+		sourceHash:   sourceHash,
+		renderer:     renderer,
+		tmplErr:      tmplErr,
+		writeTargets: writeTargets,
+		force:        forceResync,
+		backoff:      scopeable,
+		prev:         targets.prev,
+		now:          now.Time,
+	}
+	forEachLimit(len(pending), r.TargetConcurrency, func(i int) {
+		results[pending[i]] = r.syncTarget(ctx, task, namespaces[pending[i]])
+	})
+
+	// 按命名空间的顺序汇总结果，保证状态与并发度无关
+	for i, ns := range namespaces {
+		res := results[i]
+		switch res.state {
+		case syncv1.TargetStateSynced:
 			synced = append(synced, ns)
+		case syncv1.TargetStateFailed:
+			failed = append(failed, ns)
+		case syncv1.TargetStateConflicted:
+			conflicted = append(conflicted, ns)
+		}
+		if res.change != nil {
+			plan = append(plan, *res.change)
+		}
+		switch {
+		case res.carried:
+		case res.backingOff:
+			targets.carry(ns, targetSecretName)
+		case res.state == syncv1.TargetStateFailed:
+			targets.fail(ns, targetSecretName, res.message, res.hash, res.retriable)
+		default:
+			targets.record(ns, targetSecretName, res.state, res.message, res.hash)
+		}
+	}
+
+	// 清理不再属于目标集合的 Secret
+	var pruned []string
+	var pruneErr error
+	if pruneEnabled(spec) && !writeTargets {
+		var candidates []corev1.Secret
+		candidates, pruneErr = r.pruneCandidates(ctx, syncObj, namespaces, targetSecretName)
+		for _, secret := range candidates {
+			reason := "Target Secret is no longer part of the target set"
+			plan = append(plan, syncv1.PlannedChange{
+				Namespace: secret.Namespace, Name: secret.Name, Action: syncv1.PlanActionPrune, Reason: reason,
+			})
+			targets.record(secret.Namespace, secret.Name, syncv1.TargetStateDrifted, reason, "")
+		}
+	} else if pruneEnabled(spec) && scope == nil {
+		// 限定范围的调和不清理，目标集合的变化总会触发完整调和
+		var prunedSecrets []types.NamespacedName
+		prunedSecrets, pruneErr = r.pruneTargets(ctx, syncObj, namespaces, targetSecretName)
+		if pruneErr != nil {
+			log.Error(pruneErr, "Failed to prune orphaned target Secrets")
+		}
+		for _, key := range prunedSecrets {
+			pruned = append(pruned, key.Namespace)
+			targets.record(key.Namespace, key.Name, syncv1.TargetStatePruned, "", "")
 		}
 	}
 
 	// 更新 Secretsync 资源的状态
-	now := metav1.Now()
-	syncObj.Status.SyncedNamespaces = synced
-	syncObj.Status.FailedNamespaces = failed
-	syncObj.Status.LastSyncTime = &now
-	if err := r.Status().Update(ctx, &syncObj); err != nil {
-		log.Error(err, "Failed to update SecretSync status")
-		// 状态更新失败不会影响同步操作的返回结果
+	status.SyncedNamespaces = synced
+	status.FailedNamespaces = failed
+	status.PrunedNamespaces = pruned
+	status.Targets = targets.result()
+	status.Plan = nil
+	if spec.Mode == syncv1.SyncModeDryRun {
+		status.Plan = plan
+	}
+	status.SourceHash = sourceHash
+	status.LastSyncTime = &now
+	if scope == nil {
+		status.LastFullSyncTime = &now
+	}
+	// 全部目标重写成功后才记录注解值，失败或冲突的目标在下一次调和时仍会被强制重写
+	if forceResync && len(failed) == 0 && len(conflicted) == 0 {
+		status.LastHandledResyncAt = resyncAt
+	}
+	switch {
+	case len(failed) > 0:
+		markFailed(syncObj, syncv1.ReasonSyncFailed, fmt.Sprintf("%d of %d target namespaces failed to sync: %s",
+			len(failed), len(namespaces), strings.Join(failed, ", ")))
+	case len(conflicted) > 0:
+		markFailed(syncObj, syncv1.ReasonTargetConflict, fmt.Sprintf("Secret %s conflicts with other owners in: %s",
+			targetSecretName, strings.Join(conflicted, ", ")))
+	case len(skipped) > 0:
+		markFailed(syncObj, syncv1.ReasonTargetsSkipped, fmt.Sprintf(
+			"Namespaced Secretsync can only sync into its own namespace, skipped: %s; use ClusterSecretsync to sync across namespaces",
+			strings.Join(skipped, ", ")))
+	case pruneErr != nil:
+		markFailed(syncObj, syncv1.ReasonPruneFailed, pruneErr.Error())
+	case len(plan) > 0 && spec.Mode == syncv1.SyncModeDryRun:
+		markDrifted(syncObj, syncv1.ReasonChangesPending,
+			fmt.Sprintf("%d changes planned, see status.plan", len(plan)))
+	case len(plan) > 0:
+		markDrifted(syncObj, syncv1.ReasonDriftDetected,
+			fmt.Sprintf("%d target Secrets differ from the source", len(plan)))
+	default:
+		markReady(syncObj, fmt.Sprintf("Synced to %d target namespaces", len(synced)))
 	}
+	// 状态更新失败不会影响同步操作的返回结果
+	r.updateStatus(ctx, syncObj)
 
 	// 更新 Prometheus 指标
+	recordDrift(syncObj, len(plan))
 	if len(synced) > 0 && len(failed) == 0 {
 		// 全部同步成功
 		syncTotalCounter.WithLabelValues("success").Inc()
@@ -226,138 +517,640 @@ func (r *SecretsyncReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	latency := time.Since(start).Seconds()
 	syncLatencySeconds.Observe(latency)
 
-	// 确定下次调和的间隔时间
-	// 使用用户指定的 SyncInterval 或默认值 180 秒
-	syncInterval := 180
-	if syncObj.Spec.SyncInterval > 0 {
-		syncInterval = syncObj.Spec.SyncInterval
+	// 完整调和后加入随机抖动，避免同时创建的对象始终在同一时刻集中调和；
+	// 限定范围的调和不推迟下一次完整调和
+	requeueAfter := wait.Jitter(syncInterval, resyncJitterFactor)
+	if scope != nil {
+		requeueAfter = max(status.LastFullSyncTime.Add(syncInterval).Sub(now.Time), time.Second)
+	}
+
+	// 失败的目标按各自的退避时间重试，不再让整个对象进入限速重试；
+	// 重试早于下一次完整调和时只检查这些命名空间，健康的目标仍按周期调和的间隔检查
+	if retrying, next := retryingTargets(status.Targets); len(retrying) > 0 {
+		if retryAfter := max(next.Sub(now.Time), time.Second); retryAfter < requeueAfter {
+			requeueAfter = retryAfter
+			for _, ns := range retrying {
+				r.scopes.markNamespace(req.NamespacedName, ns)
+			}
+			log.Info("Retrying failed target namespaces", "namespaces", retrying, "after", retryAfter)
+		}
 	}
 
-	// 如果有任何命名空间同步失败，返回错误以触发重新排队
-	if len(failed) > 0 {
-		// 即使有失败，也按照指定间隔进行下一次调和
-		return ctrl.Result{RequeueAfter: time.Duration(syncInterval) * time.Second}, fmt.Errorf("some target namespaces failed to sync")
+	// 清理失败时返回错误以触发重试
+	if pruneErr != nil {
+		return ctrl.Result{RequeueAfter: requeueAfter}, pruneErr
 	}
 
 	// 所有同步都成功，按照指定间隔进行下一次调和
-	return ctrl.Result{RequeueAfter: time.Duration(syncInterval) * time.Second}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// renderNamespaceData 针对目标命名空间渲染数据模板
+// 模板可以访问命名空间的标签，因此需要先读取命名空间对象
+func (r *SecretsyncReconciler) renderNamespaceData(
+	ctx context.Context,
+	renderer *dataRenderer,
+	data map[string][]byte,
+	namespace string,
+) (map[string][]byte, error) {
+	var ns metav1.PartialObjectMetadata
+	ns.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		return nil, err
+	}
+	return renderer.render(data, ns.Name, ns.Labels)
+}
+
+// updateStatus 写回 Secretsync 的状态，失败时仅记录日志
+func (r *SecretsyncReconciler) updateStatus(ctx context.Context, syncObj syncObject) {
+	if err := r.Status().Update(ctx, syncObj); err != nil {
+		r.Log.Error(err, "Failed to update SecretSync status", "secretsync", client.ObjectKeyFromObject(syncObj))
+	}
+}
+
+// targetTask 是一次调和中所有目标命名空间共享的只读输入
+type targetTask struct {
+	log          logr.Logger
+	source       *corev1.Secret
+	syncObj      syncObject
+	name         string
+	data         map[string][]byte
+	dataHash     string
+	sourceHash   string
+	renderer     *dataRenderer
+	tmplErr      error
+	writeTargets bool
+	force        bool
+	// 为 true 时跳过仍在退避中的失败目标
+	backoff bool
+	// 上一次调和记录的目标状态，按 namespace/name 索引，只读
+	prev map[string]syncv1.TargetStatus
+	now  time.Time
+}
+
+// targetResult 是单个目标命名空间的检查或同步结果
+type targetResult struct {
+	state   syncv1.TargetState
+	message string
+	hash    string
+	// Audit 与 DryRun 模式下未写入的变更
+	change *syncv1.PlannedChange
+	// 沿用上一次的结果，已经由 targetRecorder.carry 记录
+	carried bool
+	// 失败的目标仍在退避中，沿用上一次的失败记录
+	backingOff bool
+	// 失败是否值得按退避重试
+	retriable bool
+}
+
+// failedResult 根据写入或读取目标时的错误构造失败结果，hash 为期望写入的数据哈希
+func failedResult(err error, hash string) targetResult {
+	return targetResult{
+		state:     syncv1.TargetStateFailed,
+		message:   err.Error(),
+		hash:      hash,
+		retriable: isRetriable(err),
+	}
+}
+
+// syncTarget 检查并同步单个命名空间中的目标 Secret
+// 会被多个 worker 并发调用，只能读取 task，不能修改共享状态
+func (r *SecretsyncReconciler) syncTarget(ctx context.Context, task *targetTask, ns string) targetResult {
+	log := task.log
+	spec := task.syncObj.SyncSpec()
+	name := task.name
+
+	// 目标名称默认与源相同，目标命名空间包含源所在的命名空间时不能覆盖源本身
+	if isSourceSecret(task.syncObj, ns, name) {
+		return targetResult{state: syncv1.TargetStateSkipped, message: "Target is the source Secret"}
+	}
+
+	// 模板数据依赖目标命名空间，模板错误只影响当前命名空间
+	if task.tmplErr != nil {
+		// 模板解析错误只能通过修改 spec 解决，不安排重试
+		return targetResult{state: syncv1.TargetStateFailed, message: task.tmplErr.Error()}
+	}
+	// 每个命名空间使用自己的数据副本，task.data 可能就是源 Secret 的 Data，
+	// 并发写入时不能让多个 goroutine 共享同一个 map
+	nsData, nsHash := maps.Clone(task.data), task.dataHash
+	if task.renderer != nil {
+		rendered, err := r.renderNamespaceData(ctx, task.renderer, task.data, ns)
+		if err != nil {
+			log.Error(err, "Failed to render data template", "namespace", ns)
+			return failedResult(err, "")
+		}
+		nsData, nsHash = rendered, hashSecretData(rendered, task.source.Type)
+	}
+
+	// 上一次失败的目标在退避期内且期望数据不变时不再访问 API server
+	if prev, ok := task.prev[ns+"/"+name]; ok && task.backoff && backingOff(prev, nsHash, task.now) {
+		return targetResult{state: syncv1.TargetStateFailed, backingOff: true}
+	}
+
+	needSync := false
+	action, reason := syncv1.PlanActionUpdate, ""
+
+	// 检查目标 Secret 是否存在
+	var targetSecret corev1.Secret
+	targetKey := types.NamespacedName{Namespace: ns, Name: name}
+	err := r.getSecret(ctx, targetKey, &targetSecret)
+
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			// 获取目标 Secret 出错
+			log.Error(err, "Failed to get target Secret", "namespace", ns, "name", name)
+			return failedResult(err, nsHash)
+		}
+		// 目标 Secret 不存在，需要同步
+		log.Info("Target Secret not found, will sync", "namespace", ns, "name", name)
+		needSync = true
+		action, reason = syncv1.PlanActionCreate, "Target Secret does not exist"
+	} else if !isManaged(&targetSecret) && spec.ConflictPolicy == syncv1.ConflictPolicySkip {
+		// 已存在不受管理的同名 Secret，按 Skip 策略保持原样
+		log.Info("Target Secret is not managed by secretsync, skipping", "namespace", ns, "name", name)
+		return targetResult{state: syncv1.TargetStateConflicted,
+			message: "Secret exists and is not managed by secretsync-controller"}
+	} else if reason = targetDiff(&targetSecret, desiredTarget(task.source, nsData, task.sourceHash, ns, name, task.syncObj,
+		claimsTarget(&targetSecret, spec.ConflictPolicy))); reason != "" {
+		// 数据、类型或 targetMetadata 不一致，或旧版本创建的目标 Secret 缺少所有者标签
+		log.Info("Target Secret outdated, will sync", "namespace", ns, "name", name, "reason", reason)
+		needSync = true
+	} else if task.force {
+		needSync = true
+	}
+
+	if !needSync {
+		// 不需要同步，记录为成功
+		return targetResult{state: syncv1.TargetStateSynced, hash: nsHash}
+	}
+
+	// Audit 与 DryRun 模式下只记录偏差，不写入
+	if !task.writeTargets {
+		return targetResult{
+			state:   syncv1.TargetStateDrifted,
+			message: reason,
+			change:  &syncv1.PlannedChange{Namespace: ns, Name: name, Action: action, Reason: reason},
+		}
+	}
+
+	if err := r.syncSecret(ctx, task.source, nsData, task.sourceHash, ns, name, task.syncObj, task.force); err != nil {
+		// 同步到当前命名空间失败，记录错误
+		if errors.IsConflict(err) {
+			// 其他以 apply 写入的管理者持有冲突的字段，不覆盖
+			log.Info("Target Secret fields are owned by another manager", "namespace", ns, "name", name, "error", err.Error())
+			return targetResult{state: syncv1.TargetStateConflicted, message: err.Error()}
+		}
+		log.Error(err, "Failed to sync secret to namespace", "namespace", ns)
+		return failedResult(err, nsHash)
+	}
+	return targetResult{state: syncv1.TargetStateSynced, hash: nsHash}
 }
 
 // syncSecret 将单个源 Secret 同步到目标命名空间中
+// 目标以 server-side apply 写入，控制器只拥有数据、类型以及自己的标签和注解。
+// 手动修改过的这些字段会被恢复；以 apply 声明了冲突字段的其他管理者
+// 会导致 409 Conflict 错误，而不是被覆盖
 // 参数:
 // - ctx: 上下文，用于API通信
 // - src: 源 Secret 对象
+// - data: 写入目标 Secret 的数据（已完成筛选、重命名与模板渲染）
+// - sourceHash: 源内容的哈希，写入目标的 source-hash 注解
 // - namespace: 目标命名空间
 // - targetSecretName: 在目标命名空间中创建的 Secret 名称
 // - syncObj: Secretsync 对象，用于设置所有者引用
+// - force: 即使数据与元数据一致也重写目标 Secret，并夺取冲突字段的所有权（resync-at 注解触发）
 func (r *SecretsyncReconciler) syncSecret(
 	ctx context.Context,
 	src *corev1.Secret,
+	data map[string][]byte,
+	sourceHash string,
 	namespace, targetSecretName string,
-	syncObj *syncv1.Secretsync,
+	syncObj syncObject,
+	force bool,
 ) error {
-	// 创建目标 Secret 对象
-	target := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      targetSecretName,
-			Namespace: namespace,
-			Labels: map[string]string{
-				"secretsync.example.com/managed-by":       "secretsync-controller",
-				"secretsync.example.com/source-namespace": src.Namespace,
-				"secretsync.example.com/source-name":      src.Name,
-			},
-		},
-		Data: src.Data, // 复制源 Secret 的数据
-		Type: src.Type, // 复制源 Secret 的类型
-	}
-
-	// 设置控制器引用，使 Secret 成为 Secretsync 的子资源
-	// 注意：跨命名空间的所有者引用可能会导致问题
-	controllerutil.SetControllerReference(syncObj, target, r.Scheme)
-
 	// 检查目标 Secret 是否已存在
 	var existing corev1.Secret
 	existingKey := types.NamespacedName{Namespace: namespace, Name: targetSecretName}
-	err := r.Get(ctx, existingKey, &existing)
+	err := r.getSecret(ctx, existingKey, &existing)
 
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Secret 不存在，创建新的
 			r.Log.Info("Creating new Secret", "namespace", namespace, "name", targetSecretName)
-			return r.Create(ctx, target)
+			target := desiredTarget(src, data, sourceHash, namespace, targetSecretName, syncObj, true)
+			if err := r.setOwner(syncObj, target); err != nil {
+				return err
+			}
+			return r.applyTarget(ctx, nil, target, false)
 		}
 		return err
 	}
 
-	// Secret 已存在，检查是否需要更新
-	// 只有当数据或类型发生变化时才更新
-	if !reflect.DeepEqual(existing.Data, target.Data) || existing.Type != target.Type {
-		r.Log.Info("Updating existing Secret", "namespace", namespace, "name", targetSecretName)
-		existing.Data = target.Data
-		existing.Type = target.Type
+	// Secret 已存在但不受控制器管理时，按冲突策略决定是否接管
+	policy := syncObj.SyncSpec().ConflictPolicy
+	if !isManaged(&existing) && policy == syncv1.ConflictPolicySkip {
+		return fmt.Errorf("secret %s/%s exists and is not managed by secretsync-controller", namespace, targetSecretName)
+	}
+	// 接管时维护管理标签；Overwrite 策略下只覆盖数据，不改变所有权
+	claim := claimsTarget(&existing, policy)
+	target := desiredTarget(src, data, sourceHash, namespace, targetSecretName, syncObj, claim)
 
-		// 确保标签被正确设置
-		if existing.Labels == nil {
-			existing.Labels = make(map[string]string)
+	// 只有当数据、类型或（接管时的）标签与注解发生变化，或被要求强制重写时才写入
+	if !force && targetDiff(&existing, target) == "" {
+		r.Log.Info("Secret is up to date", "namespace", namespace, "name", targetSecretName)
+		return nil
+	}
+
+	r.Log.Info("Applying existing Secret", "namespace", namespace, "name", targetSecretName)
+	if claim {
+		if !isManaged(&existing) {
+			r.Log.Info("Adopting unmanaged Secret", "namespace", namespace, "name", targetSecretName)
+		}
+		// 所有者引用也由 apply 维护，每次都需要带上，否则会被移除
+		if err := r.setOwner(syncObj, target); err != nil {
+			return err
 		}
-		existing.Labels["secretsync.example.com/managed-by"] = "secretsync-controller"
-		existing.Labels["secretsync.example.com/source-namespace"] = src.Namespace
-		existing.Labels["secretsync.example.com/source-name"] = src.Name
+	}
+	// 是否夺取字段所有权按持有冲突字段的管理者决定：
+	// - 不受管理的 Secret 只有在 Adopt 与 Overwrite 策略明确要求接管时才会走到这里，总是夺取
+	// - resync-at 是明确要求重新推送的操作，总是夺取
+	// - 以 Update 手动修改（如 kubectl edit）的字段被夺回并恢复为期望值
+	// - 以 apply 声明了这些字段的其他管理者（如 GitOps 工具）不被覆盖，冲突以 409 返回并报告为 Conflicted
+	// 其他管理者额外写入的、控制器不关心的键始终保持不变
+	force = force || !isManaged(&existing) || !appliedByOthers(&existing, target)
+	return r.applyTarget(ctx, &existing, target, force)
+}
 
-		return r.Update(ctx, &existing)
+// setOwner 设置控制器引用，使 Secret 成为 Secretsync 的子资源
+// 跨命名空间的所有者引用会被垃圾回收视为无效，因此只在同一命名空间内
+// 或所有者为集群级 ClusterSecretsync 时设置，其余目标 Secret 依靠 finalizer 清理
+func (r *SecretsyncReconciler) setOwner(syncObj syncObject, secret *corev1.Secret) error {
+	if syncObj.GetNamespace() != "" && secret.Namespace != syncObj.GetNamespace() {
+		return nil
 	}
+	return controllerutil.SetControllerReference(syncObj, secret, r.Scheme)
+}
 
-	// 数据和类型没有变化，无需更新
-	r.Log.Info("Secret is up to date", "namespace", namespace, "name", targetSecretName)
+// isManaged 判断 Secret 是否由 secretsync-controller 管理
+func isManaged(secret *corev1.Secret) bool {
+	return secret.Labels[managedByLabel] == managedByValue
+}
+
+// claimsTarget 判断控制器是否（应当）拥有该目标 Secret
+// 已受管理的 Secret 或按 Adopt 策略接管的 Secret 都需要维护管理标签
+func claimsTarget(secret *corev1.Secret, policy syncv1.ConflictPolicy) bool {
+	return isManaged(secret) || policy == syncv1.ConflictPolicyAdopt
+}
+
+// targetLabels 返回目标 Secret 应携带的管理标签
+// 所有者标签用于在删除 Secretsync 时找回它同步出去的全部 Secret
+func targetLabels(src *corev1.Secret, syncObj syncObject) map[string]string {
+	// targetMetadata 中的标签优先级低于管理标签，不能覆盖后者
+	labels, _ := desiredMetadata(syncObj.SyncSpec(), src)
+	labels[managedByLabel] = managedByValue
+	labels[sourceNamespaceLabel] = src.Namespace
+	labels[sourceNameLabel] = src.Name
+	labels[watchedLabel] = watchedValue
+	for k, v := range ownerLabels(syncObj) {
+		labels[k] = v
+	}
+	return labels
+}
+
+// targetAnnotations 返回目标 Secret 应携带的注解（来自 targetMetadata）
+func targetAnnotations(src *corev1.Secret, syncObj syncObject) map[string]string {
+	_, annotations := desiredMetadata(syncObj.SyncSpec(), src)
+	return annotations
+}
+
+// ownerLabels 返回用于查找某个 Secretsync 所管理的目标 Secret 的标签集合
+// 集群级对象没有命名空间，因此不携带 owner-namespace 标签，靠 owner-kind 区分
+func ownerLabels(syncObj syncObject) client.MatchingLabels {
+	labels := client.MatchingLabels{
+		managedByLabel: managedByValue,
+		ownerKindLabel: syncKind(syncObj),
+		ownerNameLabel: syncObj.GetName(),
+	}
+	if syncObj.GetNamespace() != "" {
+		labels[ownerNamespaceLabel] = syncObj.GetNamespace()
+	}
+	return labels
+}
+
+// syncKind 返回 Secretsync 对象的类型名称
+func syncKind(syncObj syncObject) string {
+	if _, ok := syncObj.(*syncv1.ClusterSecretsync); ok {
+		return "ClusterSecretsync"
+	}
+	return "Secretsync"
+}
+
+// validateNamespacedSpec 校验命名空间级 Secretsync 的源与目标
+// 控制器可以读取全部命名空间的 Secret，源 Secret 必须位于 Secretsync 自身所在的命名空间，
+// 否则租户可以借此复制任意命名空间的 Secret；跨命名空间的同步需要使用 ClusterSecretsync。
+// 目标同样只能位于该命名空间，因此必须显式指定与源不同的 targetSecretName，
+// 否则目标就是源 Secret 本身
+func validateNamespacedSpec(syncObj syncObject) error {
+	ns := syncObj.GetNamespace()
+	if ns == "" {
+		return nil
+	}
+	spec := syncObj.SyncSpec()
+	if spec.SourceNamespace != ns {
+		return fmt.Errorf("sourceNamespace must be %q for a namespaced Secretsync, use ClusterSecretsync to sync across namespaces", ns)
+	}
+	if spec.TargetSecretName == "" || spec.TargetSecretName == spec.SourceSecretName {
+		return fmt.Errorf("targetSecretName must be set and differ from sourceSecretName for a namespaced Secretsync")
+	}
+	return nil
+}
+
+// isSourceSecret 判断 namespace/name 是否就是同步对象的源 Secret
+// 源 Secret 永远不会被当作目标写入、清理或删除
+func isSourceSecret(syncObj syncObject, namespace, name string) bool {
+	spec := syncObj.SyncSpec()
+	return namespace == spec.SourceNamespace && name == spec.SourceSecretName
+}
+
+// restrictNamespaces 限制命名空间级 Secretsync 的目标范围
+// 租户只能将 Secret 同步到 Secretsync 所在的命名空间，其余命名空间作为跳过项返回
+// 集群级的 ClusterSecretsync 不受限制
+func restrictNamespaces(syncObj syncObject, namespaces []string) (allowed, skipped []string) {
+	if syncObj.GetNamespace() == "" {
+		return namespaces, nil
+	}
+	for _, ns := range namespaces {
+		if ns == syncObj.GetNamespace() {
+			allowed = append(allowed, ns)
+		} else {
+			skipped = append(skipped, ns)
+		}
+	}
+	return allowed, skipped
+}
+
+// dataEqual 比较两份 Secret 数据是否一致，nil 与空数据视为相同
+func dataEqual(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		w, ok := b[k]
+		if !ok || !bytes.Equal(v, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// hashSecretData 计算 Secret 数据与类型的 sha256 哈希
+// 按键排序后逐项写入，保证相同内容得到相同的哈希
+func hashSecretData(data map[string][]byte, secretType corev1.SecretType) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	h.Write([]byte(secretType))
+	for _, k := range keys {
+		// 写入长度前缀，避免不同的键值拼接出相同的字节序列
+		fmt.Fprintf(h, "\x00%d:%s%d:", len(k), k, len(data[k]))
+		h.Write(data[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hasEntries 判断 actual 是否包含 want 中的全部键值（用于标签与注解）
+func hasEntries(actual, want map[string]string) bool {
+	for k, v := range want {
+		if actual[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// pruneEnabled 判断是否需要清理孤立的目标 Secret，未设置时默认开启
+func pruneEnabled(spec *syncv1.SecretsyncSpec) bool {
+	return spec.Prune == nil || *spec.Prune
+}
+
+// pruneCandidates 查找由该 Secretsync 管理、但已不在当前目标集合中的 Secret
+// 包括命名空间不再匹配选择器、被移出显式列表，以及 targetSecretName 变更后留下的旧 Secret
+func (r *SecretsyncReconciler) pruneCandidates(
+	ctx context.Context,
+	syncObj syncObject,
+	namespaces []string,
+	targetSecretName string,
+) ([]corev1.Secret, error) {
+	// 当前目标集合，便于快速判断
+	wanted := make(map[string]struct{}, len(namespaces))
+	for _, ns := range namespaces {
+		wanted[ns] = struct{}{}
+	}
+
+	// 通过所有者标签列出该 Secretsync 管理的全部 Secret
+	var managed corev1.SecretList
+	if err := r.List(ctx, &managed, ownerLabels(syncObj)); err != nil {
+		return nil, err
+	}
+
+	var candidates []corev1.Secret
+	for _, secret := range managed.Items {
+		if _, ok := wanted[secret.Namespace]; ok && secret.Name == targetSecretName ||
+			isSourceSecret(syncObj, secret.Namespace, secret.Name) {
+			continue
+		}
+		candidates = append(candidates, secret)
+	}
+	return candidates, nil
+}
+
+// pruneTargets 删除 pruneCandidates 找到的目标 Secret，返回被删除的 Secret
+func (r *SecretsyncReconciler) pruneTargets(
+	ctx context.Context,
+	syncObj syncObject,
+	namespaces []string,
+	targetSecretName string,
+) ([]types.NamespacedName, error) {
+	candidates, err := r.pruneCandidates(ctx, syncObj, namespaces, targetSecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []types.NamespacedName
+	var errs []error
+	for i := range candidates {
+		secret := &candidates[i]
+		r.Log.Info("Pruning orphaned target Secret", "namespace", secret.Namespace, "name", secret.Name)
+		if err := r.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("prune %s/%s: %w", secret.Namespace, secret.Name, err))
+			continue
+		}
+		pruned = append(pruned, client.ObjectKeyFromObject(secret))
+	}
+	return pruned, kerrors.NewAggregate(errs)
+}
+
+// finalize 在 Secretsync 删除时按删除策略处理目标 Secret，完成后移除 finalizer
+// - Delete：删除所有带有该 Secretsync 所有者标签的目标 Secret
+// - Orphan：保留目标 Secret，只移除所有者标签和所有者引用
+// 没有其他同步对象引用源 Secret 时，同时移除源 Secret 上的 watchedLabel
+func (r *SecretsyncReconciler) finalize(ctx context.Context, syncObj syncObject) error {
+	if !controllerutil.ContainsFinalizer(syncObj, secretsyncFinalizer) {
+		return nil
+	}
+
+	// 通过所有者标签查找所有目标 Secret
+	// 旧版本创建的目标 Secret 可能没有 watchedLabel，因此绕过缓存列出
+	var reader client.Reader = r.Client
+	if r.APIReader != nil {
+		reader = r.APIReader
+	}
+	var targets corev1.SecretList
+	if err := reader.List(ctx, &targets, ownerLabels(syncObj)); err != nil {
+		return err
+	}
+
+	for i := range targets.Items {
+		target := &targets.Items[i]
+		// 旧版本可能把源 Secret 当作目标接管过，只解除管理，不删除源
+		if syncObj.SyncSpec().DeletionPolicy == syncv1.DeletionPolicyOrphan ||
+			isSourceSecret(syncObj, target.Namespace, target.Name) {
+			if err := r.orphanSecret(ctx, target, syncObj); err != nil {
+				return err
+			}
+			continue
+		}
+		r.Log.Info("Deleting target Secret", "namespace", target.Namespace, "name", target.Name)
+		if err := r.Delete(ctx, target); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	// 释放当前 spec 的源，以及源变更后尚未释放的旧源
+	for _, ref := range append(indexSource(syncObj), syncObj.SyncStatus().WatchedSource) {
+		if err := r.releaseSource(ctx, syncObj, ref); err != nil {
+			return err
+		}
+	}
+
+	// 清理完成，移除 finalizer 让删除继续进行
+	namespaceExpressions.forget(syncObj.GetUID())
+	recordDrift(syncObj, 0)
+	controllerutil.RemoveFinalizer(syncObj, secretsyncFinalizer)
+	return r.Update(ctx, syncObj)
+}
+
+// orphanSecret 解除控制器对目标 Secret 的管理
+// 移除全部管理标签和指向 Secretsync 的所有者引用，避免 Secret 被垃圾回收；
+// 保留下来的 Secret 不再被视为受管理，之后的同步对象只能按冲突策略处理它
+func (r *SecretsyncReconciler) orphanSecret(ctx context.Context, target *corev1.Secret, syncObj syncObject) error {
+	r.Log.Info("Orphaning target Secret", "namespace", target.Namespace, "name", target.Name)
+	patch := client.MergeFrom(target.DeepCopy())
+	delete(target.Labels, managedByLabel)
+	delete(target.Labels, sourceNamespaceLabel)
+	delete(target.Labels, sourceNameLabel)
+	delete(target.Labels, ownerKindLabel)
+	delete(target.Labels, ownerNamespaceLabel)
+	delete(target.Labels, ownerNameLabel)
+	delete(target.Labels, watchedLabel)
+
+	var refs []metav1.OwnerReference
+	for _, ref := range target.OwnerReferences {
+		if ref.UID != syncObj.GetUID() {
+			refs = append(refs, ref)
+		}
+	}
+	target.OwnerReferences = refs
+
+	if err := r.Patch(ctx, target, patch); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
 	return nil
 }
 
 // getMatchingNamespaces 根据标签选择器和显式指定的命名空间列表获取匹配的命名空间
 // 参数:
 // - ctx: 上下文，用于API通信
-// - selector: Kubernetes 标签选择器
-// - explicitNamespaces: 显式指定的命名空间列表
+// - syncObj: 同步对象，其 spec 提供目标命名空间列表、选择器、模式、表达式以及排除规则
 // 返回:
-// - 匹配的命名空间名称列表（合并所有来源的命名空间并去重）
+// - 匹配的命名空间名称列表（合并所有来源的命名空间并去重，再去除被排除的命名空间）
 // - 错误（如果有）
 func (r *SecretsyncReconciler) getMatchingNamespaces(
 	ctx context.Context,
-	selector *metav1.LabelSelector,
-	explicitNamespaces []string,
+	syncObj syncObject,
 ) ([]string, error) {
+	spec := syncObj.SyncSpec()
 	// 用于存储最终结果的映射，便于去重
 	result := make(map[string]struct{})
 
 	// 首先添加所有显式指定的命名空间
-	for _, ns := range explicitNamespaces {
+	for _, ns := range spec.TargetNamespaces {
 		result[ns] = struct{}{}
 	}
 
-	// 如果没有提供选择器，直接返回显式指定的命名空间
-	if selector == nil {
-		// 将映射转为字符串数组
-		var namespaces []string
-		for ns := range result {
-			namespaces = append(namespaces, ns)
+	if spec.TargetNamespaceSelector != nil {
+		// 将 LabelSelector 转换为 Selector 接口
+		selectorLabels, err := metav1.LabelSelectorAsSelector(spec.TargetNamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		// 列出所有匹配选择器的命名空间
+		nsList, err := r.listNamespaces(ctx, client.MatchingLabelsSelector{Selector: selectorLabels})
+		if err != nil {
+			return nil, err
+		}
+
+		// 将通过标签选择器找到的命名空间添加到结果中
+		for _, ns := range nsList {
+			result[ns.Name] = struct{}{}
 		}
-		return namespaces, nil
 	}
 
-	// 将 LabelSelector 转换为 Selector 接口
-	selectorLabels, err := metav1.LabelSelectorAsSelector(selector)
+	// 名称模式与 CEL 表达式需要列出全部命名空间后逐一比较
+	patterns, err := compileNamespacePatterns(spec.TargetNamespacePatterns)
 	if err != nil {
 		return nil, err
 	}
-
-	// 列出所有匹配选择器的命名空间
-	var nsList corev1.NamespaceList
-	if err := r.List(ctx, &nsList, client.MatchingLabelsSelector{Selector: selectorLabels}); err != nil {
+	program, err := namespaceExpressions.get(syncObj)
+	if err != nil {
 		return nil, err
 	}
+	if len(patterns) > 0 || program != nil {
+		nsList, err := r.listNamespaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range nsList {
+			ns := &nsList[i]
+			if matchesAnyPattern(patterns, ns.Name) || (program != nil && matchesNamespaceExpression(program, ns)) {
+				result[ns.Name] = struct{}{}
+			}
+		}
+	}
 
-	// 将通过标签选择器找到的命名空间添加到结果中
-	for _, ns := range nsList.Items {
-		result[ns.Name] = struct{}{}
+	// 在合并结果之后应用排除规则
+	for ns := range result {
+		if isExcludedByName(spec, ns, r.ExcludedNamespaces) {
+			delete(result, ns)
+		}
+	}
+	if spec.ExcludeNamespaceSelector != nil {
+		excludeSelector, err := metav1.LabelSelectorAsSelector(spec.ExcludeNamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+		excluded, err := r.listNamespaces(ctx, client.MatchingLabelsSelector{Selector: excludeSelector})
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range excluded {
+			delete(result, ns.Name)
+		}
 	}
 
 	// 将映射转为字符串数组
@@ -365,71 +1158,176 @@ func (r *SecretsyncReconciler) getMatchingNamespaces(
 	for ns := range result {
 		namespaces = append(namespaces, ns)
 	}
+	// 排序后结果稳定，状态中的命名空间顺序不会在每次调和时抖动
+	sort.Strings(namespaces)
 	return namespaces, nil
 }
 
+// listSyncObjects 列出集群中的 Secretsync 对象，opts 通常是字段索引条件
+func (r *SecretsyncReconciler) listSyncObjects(ctx context.Context, opts ...client.ListOption) ([]syncObject, error) {
+	var list syncv1.SecretsyncList
+	if err := r.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	items := make([]syncObject, 0, len(list.Items))
+	for i := range list.Items {
+		items = append(items, &list.Items[i])
+	}
+	return items, nil
+}
+
 // enqueueSecrets 是一个 MapFunc，当监视的 Secret 发生变化时
 // 确定哪些 Secretsync 对象需要被重新调和
 // 返回需要重新调和的 Secretsync 请求列表
-func (r *SecretsyncReconciler) enqueueSecrets(_ context.Context, obj client.Object) []reconcile.Request {
-	ctx := context.TODO()
-	// 转换为 Secret 对象
+func (r *SecretsyncReconciler) enqueueSecrets(ctx context.Context, obj client.Object) []reconcile.Request {
+	// 通过源索引只列出以该 Secret 为源的 Secretsync
 	secret := obj.(*corev1.Secret)
+	items, err := r.listSyncObjects(ctx,
+		client.MatchingFields{sourceIndexField: sourceIndexValue(secret.Namespace, secret.Name)})
+	if err != nil {
+		r.Log.Error(err, "Failed to list SecretSync CRs")
+		return nil
+	}
+	return r.mapSecret(ctx, secret, items, "Secretsync")
+}
 
-	// 列出所有 Secretsync 对象
-	var list syncv1.SecretsyncList
-	if err := r.List(ctx, &list, &client.ListOptions{}); err != nil {
+// enqueueNamespaces 是一个 MapFunc，当监视的 Namespace 发生变化时
+// 确定哪些 Secretsync 对象需要被重新调和
+func (r *SecretsyncReconciler) enqueueNamespaces(ctx context.Context, obj client.Object) []reconcile.Request {
+	// 通过目标命名空间索引列出可能匹配的 Secretsync
+	// Namespace 以仅元数据的方式监视，obj 为 PartialObjectMetadata
+	items, err := listNamespaceCandidates(ctx, obj.GetName(), r.listSyncObjects)
+	if err != nil {
 		r.Log.Error(err, "Failed to list SecretSync CRs")
 		return nil
 	}
+	requests := requestsForNamespace(obj, items, r.ExcludedNamespaces)
+	// 命名空间不再匹配时新对象不会命中上面的检查，还需要通知已同步到该命名空间的对象，以便及时清理
+	requests = append(requests, r.requestsForTargetsIn(ctx, obj.GetName(), "Secretsync")...)
+	r.scopes.markFull(requests)
+	return requests
+}
+
+// mapSecret 将 Secret 事件映射为调和请求
+// 作为源的 Secret 触发完整调和；由控制器管理的目标 Secret 被删除或修改时，
+// 通过所有者标签直接找到对应的对象，并只重新检查该命名空间，几秒内即可修复。
+// 与源事件一样，所有者已暂停时忽略目标事件
+func (r *SecretsyncReconciler) mapSecret(
+	ctx context.Context,
+	secret *corev1.Secret,
+	items []syncObject,
+	kind string,
+) []reconcile.Request {
+	requests := requestsForSecret(secret, items)
+	r.scopes.markFull(requests)
+	if req, ok := requestForTarget(secret, kind); ok && !r.ownerSuspended(ctx, req.NamespacedName, kind) {
+		r.scopes.markNamespace(req.NamespacedName, secret.Namespace)
+		requests = append(requests, req)
+	}
+	return requests
+}
+
+// requestsForTargetsIn 返回在 namespace 中拥有目标 Secret 的同步对象的调和请求
+// kind 为 Secretsync 或 ClusterSecretsync，已暂停的所有者被忽略
+func (r *SecretsyncReconciler) requestsForTargetsIn(ctx context.Context, namespace, kind string) []reconcile.Request {
+	var targets corev1.SecretList
+	if err := r.List(ctx, &targets, client.InNamespace(namespace),
+		client.MatchingLabels{managedByLabel: managedByValue, ownerKindLabel: kind}); err != nil {
+		r.Log.Error(err, "Failed to list target Secrets", "namespace", namespace)
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range targets.Items {
+		if req, ok := requestForTarget(&targets.Items[i], kind); ok && !r.ownerSuspended(ctx, req.NamespacedName, kind) {
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
+
+// ownerSuspended 判断目标 Secret 的所有者是否已暂停
+// 读取失败（包括所有者已被删除）时返回 false，交给调和处理
+func (r *SecretsyncReconciler) ownerSuspended(ctx context.Context, key types.NamespacedName, kind string) bool {
+	var owner syncObject = &syncv1.Secretsync{}
+	if kind == "ClusterSecretsync" {
+		owner = &syncv1.ClusterSecretsync{}
+	}
+	if err := r.Get(ctx, key, owner); err != nil {
+		return false
+	}
+	return owner.SyncSpec().Suspend
+}
 
-	// 查找使用此 Secret 作为源的所有 Secretsync 对象
+// requestsForSecret 查找使用此 Secret 作为源的所有同步对象
+func requestsForSecret(secret *corev1.Secret, items []syncObject) []reconcile.Request {
 	var requests []reconcile.Request
-	for _, item := range list.Items {
+	for _, item := range items {
+		spec := item.SyncSpec()
+		// 已暂停的对象忽略源变化，恢复时会重新调和
+		if spec.Suspend {
+			continue
+		}
 		// 检查 Secret 是否是该 Secretsync 的源
-		if item.Spec.SourceNamespace == secret.Namespace && item.Spec.SourceSecretName == secret.Name {
+		if spec.SourceNamespace == secret.Namespace && spec.SourceSecretName == secret.Name {
 			requests = append(requests, reconcile.Request{
-				NamespacedName: client.ObjectKeyFromObject(&item),
+				NamespacedName: client.ObjectKeyFromObject(item),
 			})
 		}
 	}
 	return requests
 }
 
-// enqueueNamespaces 是一个 MapFunc，当监视的 Namespace 发生变化时
-// 确定哪些 Secretsync 对象需要被重新调和
-func (r *SecretsyncReconciler) enqueueNamespaces(_ context.Context, obj client.Object) []reconcile.Request {
-	ctx := context.TODO()
-	// 转换为 Namespace 对象
-	ns := obj.(*corev1.Namespace)
-
-	// 列出所有 Secretsync 对象
-	var list syncv1.SecretsyncList
-	if err := r.List(ctx, &list, &client.ListOptions{}); err != nil {
-		r.Log.Error(err, "Failed to list SecretSync CRs")
-		return nil
-	}
-
-	// 查找所有可能使用此命名空间作为目标的 Secretsync 对象
+// requestsForNamespace 查找所有可能使用此命名空间作为目标的同步对象
+// 被排除的命名空间不会触发调和，excluded 为控制器级的排除列表
+func requestsForNamespace(ns metav1.Object, items []syncObject, excluded []string) []reconcile.Request {
 	var requests []reconcile.Request
-	for _, item := range list.Items {
+	for _, item := range items {
+		// 命名空间级的 Secretsync 只会同步到自身所在的命名空间
+		if item.GetNamespace() != "" && item.GetNamespace() != ns.GetName() {
+			continue
+		}
+		spec := item.SyncSpec()
+		// 按名称排除的命名空间不会因为命名空间事件而改变，直接忽略；
+		// 命名空间新增了匹配 excludeNamespaceSelector 的标签时仍需调和，以便清理其中已有的目标
+		if spec.Suspend || isExcludedByName(spec, ns.GetName(), excluded) {
+			continue
+		}
+
 		// 检查是否在显式指定的命名空间列表中
-		for _, targetNs := range item.Spec.TargetNamespaces {
-			if targetNs == ns.Name {
+		for _, targetNs := range spec.TargetNamespaces {
+			if targetNs == ns.GetName() {
 				requests = append(requests, reconcile.Request{
-					NamespacedName: client.ObjectKeyFromObject(&item),
+					NamespacedName: client.ObjectKeyFromObject(item),
 				})
 				// 已找到匹配，跳出内层循环
 				goto nextItem
 			}
 		}
 
+		// 检查命名空间名称是否匹配模式，新建的命名空间可以立即收到 Secret
+		if patterns, err := compileNamespacePatterns(spec.TargetNamespacePatterns); err == nil &&
+			matchesAnyPattern(patterns, ns.GetName()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(item),
+			})
+			continue
+		}
+
+		// 检查命名空间是否满足 CEL 表达式
+		if program, err := namespaceExpressions.get(item); err == nil && program != nil &&
+			matchesNamespaceExpression(program, ns) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(item),
+			})
+			continue
+		}
+
 		// 检查命名空间是否匹配选择器
-		if item.Spec.TargetNamespaceSelector != nil {
-			sel, _ := metav1.LabelSelectorAsSelector(item.Spec.TargetNamespaceSelector)
-			if sel.Matches(labels.Set(ns.Labels)) {
+		if spec.TargetNamespaceSelector != nil {
+			sel, _ := metav1.LabelSelectorAsSelector(spec.TargetNamespaceSelector)
+			if sel.Matches(labels.Set(ns.GetLabels())) {
 				requests = append(requests, reconcile.Request{
-					NamespacedName: client.ObjectKeyFromObject(&item),
+					NamespacedName: client.ObjectKeyFromObject(item),
 				})
 			}
 		}
@@ -443,19 +1341,45 @@ func (r *SecretsyncReconciler) enqueueNamespaces(_ context.Context, obj client.O
 // SetupWithManager 设置控制器与管理器的关联
 // 定义控制器监视哪些资源，以及如何处理这些资源的变化
 func (r *SecretsyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.scopes = newReconcileScopes()
+	if err := setupIndexes(mgr, &syncv1.Secretsync{}); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		// 主要关注 Secretsync 资源的变化
 		For(&syncv1.Secretsync{}).
-		// 监视 Secret 资源的变化，并通过 enqueueSecrets 确定需要调和的 Secretsync
+		// 监视 Secret 资源的变化（包括源 Secret 与受管理的目标 Secret），
+		// 并通过 enqueueSecrets 确定需要调和的 Secretsync
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueSecrets),
+			builder.WithPredicates(secretPredicate()),
 		).
-		// 监视 Namespace 资源的变化，并通过 enqueueNamespaces 确定需要调和的 Secretsync
-		Watches(
+		// 以仅元数据的方式监视 Namespace 的变化，并通过 enqueueNamespaces 确定需要调和的 Secretsync
+		WatchesMetadata(
 			&corev1.Namespace{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueNamespaces),
+			builder.WithPredicates(namespacePredicate()),
 		).
+		WithOptions(r.controllerOptions()).
 		// 完成控制器设置
 		Complete(r)
 }
+
+// recordDrift 更新 Audit 与 DryRun 模式下的偏差指标，Enforce 模式或对象删除时移除对应序列
+func recordDrift(syncObj syncObject, drifted int) {
+	mode := syncObj.SyncSpec().Mode
+	for _, m := range []syncv1.SyncMode{syncv1.SyncModeAudit, syncv1.SyncModeDryRun} {
+		labels := prometheus.Labels{
+			"kind":      syncKind(syncObj),
+			"namespace": syncObj.GetNamespace(),
+			"name":      syncObj.GetName(),
+			"mode":      string(m),
+		}
+		if m == mode && syncObj.GetDeletionTimestamp().IsZero() {
+			driftedTargetsGauge.With(labels).Set(float64(drifted))
+		} else {
+			driftedTargetsGauge.Delete(labels)
+		}
+	}
+}
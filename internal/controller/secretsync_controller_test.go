@@ -21,8 +21,11 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -65,6 +68,16 @@ var _ = Describe("Secretsync Controller", func() {
 
 			By("Cleanup the specific resource instance Secretsync")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			By("Reconciling the deletion so that the finalizer is released")
+			controllerReconciler := &SecretsyncReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, typeNamespacedName, &syncv1.Secretsync{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
 		})
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
@@ -77,8 +90,618 @@ var _ = Describe("Secretsync Controller", func() {
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			By("Reporting the invalid spec through status conditions")
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+			ready := meta.FindStatusCondition(resource.Status.Conditions, syncv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(syncv1.ReasonInvalidSpec))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, syncv1.ConditionDegraded)).To(BeTrue())
+		})
+	})
+
+	Context("When deleting a resource", func() {
+		const (
+			resourceName = "cleanup-resource"
+			sourceName   = "cleanup-source"
+			targetName   = "cleanup-target"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		targetKey := types.NamespacedName{Name: targetName, Namespace: "default"}
+		sourceKey := types.NamespacedName{Name: sourceName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating the source Secret and the Secretsync")
+			createSource(ctx, sourceName)
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       testSpec(sourceName, targetName),
+			})
+		})
+
+		It("should delete the propagated Secrets before releasing the resource", func() {
+			controllerReconciler := newTestReconciler()
+
+			By("Reconciling the created resource")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, targetKey, &corev1.Secret{})).To(Succeed())
+
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Targets).To(HaveLen(1))
+			Expect(resource.Status.Targets[0].Namespace).To(Equal("default"))
+			Expect(resource.Status.Targets[0].State).To(Equal(syncv1.TargetStateSynced))
+			Expect(resource.Status.Targets[0].Hash).NotTo(BeEmpty())
+			source := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, sourceKey, source)).To(Succeed())
+			Expect(source.Labels).To(HaveKeyWithValue(watchedLabel, watchedValue))
+
+			By("Deleting the resource and reconciling again")
+			Expect(resource.Finalizers).To(ContainElement(secretsyncFinalizer))
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, targetKey, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			err = k8sClient.Get(ctx, typeNamespacedName, &syncv1.Secretsync{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			By("Releasing the source Secret from the cache")
+			Expect(k8sClient.Get(ctx, sourceKey, source)).To(Succeed())
+			Expect(source.Labels).NotTo(HaveKey(watchedLabel))
+		})
+
+		It("should release the previous source when the source changes", func() {
+			controllerReconciler := newTestReconciler()
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Switching to another source Secret")
+			next := createSource(ctx, "cleanup-next-source")
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.SourceSecretName = next.Name
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			source := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, sourceKey, source)).To(Succeed())
+			Expect(source.Labels).NotTo(HaveKey(watchedLabel))
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(next), source)).To(Succeed())
+			Expect(source.Labels).To(HaveKeyWithValue(watchedLabel, watchedValue))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.WatchedSource).To(Equal("default/" + next.Name))
+		})
+
+		It("should keep the source watched while another object references it", func() {
+			controllerReconciler := newTestReconciler()
+			createSyncObject(ctx, &syncv1.ClusterSecretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: "cleanup-cluster-resource"},
+				Spec:       testSpec(sourceName, "cleanup-cluster-target"),
+			})
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			source := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, sourceKey, source)).To(Succeed())
+			Expect(source.Labels).To(HaveKeyWithValue(watchedLabel, watchedValue))
+		})
+	})
+
+	Context("When the resource is suspended", func() {
+		const (
+			resourceName = "suspended-resource"
+			sourceName   = "suspended-source"
+			targetName   = "suspended-target"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		targetKey := types.NamespacedName{Name: targetName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating the source Secret and a suspended Secretsync")
+			createSource(ctx, sourceName)
+			spec := testSpec(sourceName, targetName)
+			spec.Suspend = true
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       spec,
+			})
+		})
+
+		It("should not write targets until resumed", func() {
+			controllerReconciler := newTestReconciler()
+
+			By("Reconciling the suspended resource")
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			err = k8sClient.Get(ctx, targetKey, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, syncv1.ConditionSuspended)).To(BeTrue())
+
+			By("Resuming the resource")
+			resource.Spec.Suspend = false
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, targetKey, &corev1.Secret{})).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.FindStatusCondition(resource.Status.Conditions, syncv1.ConditionSuspended)).To(BeNil())
+		})
+
+		It("should ignore events from its targets while suspended", func() {
+			controllerReconciler := newTestReconciler()
+			target := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      targetName,
+				Namespace: "default",
+				Labels: map[string]string{
+					managedByLabel:      managedByValue,
+					ownerKindLabel:      "Secretsync",
+					ownerNamespaceLabel: "default",
+					ownerNameLabel:      resourceName,
+				},
+			}}
+			Expect(controllerReconciler.mapSecret(ctx, target, nil, "Secretsync")).To(BeEmpty())
+
+			By("Resuming the resource")
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.Suspend = false
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(controllerReconciler.mapSecret(ctx, target, nil, "Secretsync")).To(ConsistOf(
+				reconcile.Request{NamespacedName: typeNamespacedName}))
+		})
+	})
+
+	Context("When running in DryRun mode", func() {
+		const (
+			resourceName = "dryrun-resource"
+			sourceName   = "dryrun-source"
+			targetName   = "dryrun-target"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		targetKey := types.NamespacedName{Name: targetName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating the source Secret and a DryRun Secretsync")
+			createSource(ctx, sourceName)
+			spec := testSpec(sourceName, targetName)
+			spec.Mode = syncv1.SyncModeDryRun
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       spec,
+			})
+		})
+
+		It("should publish the plan without writing targets", func() {
+			controllerReconciler := newTestReconciler()
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, targetKey, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Plan).To(ConsistOf(syncv1.PlannedChange{
+				Namespace: "default",
+				Name:      targetName,
+				Action:    syncv1.PlanActionCreate,
+				Reason:    "Target Secret does not exist",
+			}))
+			ready := meta.FindStatusCondition(resource.Status.Conditions, syncv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(syncv1.ReasonChangesPending))
+
+			By("Leaving the source Secret untouched")
+			source := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: sourceName, Namespace: "default"}, source)).To(Succeed())
+			Expect(source.Labels).NotTo(HaveKey(watchedLabel))
+		})
+	})
+
+	Context("When another manager changes a managed target", func() {
+		const (
+			resourceName = "edited-resource"
+			sourceName   = "edited-source"
+			targetName   = "edited-target"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		targetKey := types.NamespacedName{Name: targetName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating the source Secret and the Secretsync")
+			createSource(ctx, sourceName)
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       testSpec(sourceName, targetName),
+			})
+		})
+
+		It("should restore fields changed by kubectl edit", func() {
+			controllerReconciler := newTestReconciler()
+
+			By("Reconciling the created resource")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Editing the target with another field manager")
+			target := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			target.Data["password"] = []byte("edited")
+			target.Data["extra"] = []byte("kept")
+			Expect(k8sClient.Update(ctx, target, client.FieldOwner("kubectl-edit"))).To(Succeed())
+
+			By("Reconciling again")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			Expect(target.Data).To(HaveKeyWithValue("password", []byte("s3cr3t")))
+			Expect(target.Data).To(HaveKeyWithValue("extra", []byte("kept")))
+
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Targets).To(HaveLen(1))
+			Expect(resource.Status.Targets[0].State).To(Equal(syncv1.TargetStateSynced))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, syncv1.ConditionReady)).To(BeTrue())
+		})
+
+		It("should report fields applied by another manager as a conflict", func() {
+			controllerReconciler := newTestReconciler()
+
+			By("Reconciling the created resource")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Applying data.password with a second manager")
+			applied := &corev1.Secret{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{Name: targetName, Namespace: "default"},
+				Data:       map[string][]byte{"password": []byte("gitops")},
+			}
+			Expect(k8sClient.Patch(ctx, applied, client.Apply, client.FieldOwner("gitops"), client.ForceOwnership)).To(Succeed())
+
+			By("Reconciling again")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			target := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			Expect(target.Data).To(HaveKeyWithValue("password", []byte("gitops")))
+
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Targets).To(HaveLen(1))
+			Expect(resource.Status.Targets[0].State).To(Equal(syncv1.TargetStateConflicted))
+			ready := meta.FindStatusCondition(resource.Status.Conditions, syncv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(syncv1.ReasonTargetConflict))
+		})
+	})
+
+	Context("When a Secretsync reaches outside of its namespace", func() {
+		const (
+			resourceName = "tenant-resource"
+			sourceName   = "tenant-source"
+			targetName   = "tenant-target"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		It("should reject a source in another namespace", func() {
+			spec := testSpec("kube-root-ca.crt", targetName)
+			spec.SourceNamespace = "kube-system"
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       spec,
+			})
+
+			_, err := newTestReconciler().Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			ready := meta.FindStatusCondition(resource.Status.Conditions, syncv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(syncv1.ReasonInvalidSpec))
+			Expect(resource.Status.Targets).To(BeEmpty())
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: "default"}, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should reject a target name that defaults to the source", func() {
+			source := createSource(ctx, sourceName)
+			spec := testSpec(sourceName, "")
+			spec.ConflictPolicy = syncv1.ConflictPolicyAdopt
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       spec,
+			})
+
+			_, err := newTestReconciler().Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			ready := meta.FindStatusCondition(resource.Status.Conditions, syncv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(syncv1.ReasonInvalidSpec))
+
+			current := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(source), current)).To(Succeed())
+			Expect(current.Labels).To(BeEmpty())
+			Expect(current.Annotations).To(BeEmpty())
+			Expect(current.OwnerReferences).To(BeEmpty())
+		})
+
+		It("should report targets in other namespaces as skipped", func() {
+			other := createNamespaces(ctx, 1)[0]
+			createSource(ctx, sourceName)
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       testSpec(sourceName, targetName, "default", other),
+			})
+
+			_, err := newTestReconciler().Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: "default"}, &corev1.Secret{})).To(Succeed())
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: targetName, Namespace: other}, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Targets).To(ContainElement(And(
+				HaveField("Namespace", other),
+				HaveField("State", syncv1.TargetStateSkipped),
+			)))
+			ready := meta.FindStatusCondition(resource.Status.Conditions, syncv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(syncv1.ReasonTargetsSkipped))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, syncv1.ConditionDegraded)).To(BeTrue())
+		})
+	})
+
+	Context("When the target name is taken by an unmanaged Secret", func() {
+		const (
+			resourceName = "conflict-resource"
+			sourceName   = "conflict-source"
+			targetName   = "conflict-target"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		targetKey := types.NamespacedName{Name: targetName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating the source Secret and an unmanaged Secret with the target name")
+			createSource(ctx, sourceName)
+			existing := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: targetName, Namespace: "default"},
+				Data:       map[string][]byte{"password": []byte("mine")},
+			}
+			Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, existing))).To(Succeed())
+			})
+		})
+
+		// reconcileWithPolicy 以给定的冲突策略创建 Secretsync 并调和一次，返回目标 Secret 与 Secretsync
+		reconcileWithPolicy := func(policy syncv1.ConflictPolicy) (*corev1.Secret, *syncv1.Secretsync) {
+			spec := testSpec(sourceName, targetName)
+			spec.ConflictPolicy = policy
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       spec,
+			})
+			_, err := newTestReconciler().Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			target := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Targets).To(HaveLen(1))
+			return target, resource
+		}
+
+		It("should leave the Secret untouched with Skip", func() {
+			target, resource := reconcileWithPolicy(syncv1.ConflictPolicySkip)
+			Expect(target.Data).To(Equal(map[string][]byte{"password": []byte("mine")}))
+			Expect(target.Labels).To(BeEmpty())
+			Expect(target.Annotations).To(BeEmpty())
+
+			Expect(resource.Status.Targets[0].State).To(Equal(syncv1.TargetStateConflicted))
+			ready := meta.FindStatusCondition(resource.Status.Conditions, syncv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(syncv1.ReasonTargetConflict))
+		})
+
+		It("should take ownership with Adopt", func() {
+			target, resource := reconcileWithPolicy(syncv1.ConflictPolicyAdopt)
+			Expect(target.Data).To(HaveKeyWithValue("password", []byte("s3cr3t")))
+			Expect(target.Labels).To(HaveKeyWithValue(managedByLabel, managedByValue))
+			Expect(target.Labels).To(HaveKeyWithValue(ownerKindLabel, "Secretsync"))
+			Expect(target.Labels).To(HaveKeyWithValue(ownerNamespaceLabel, "default"))
+			Expect(target.Labels).To(HaveKeyWithValue(ownerNameLabel, resourceName))
+			Expect(resource.Status.Targets[0].State).To(Equal(syncv1.TargetStateSynced))
+		})
+
+		It("should write the data without taking ownership with Overwrite", func() {
+			target, resource := reconcileWithPolicy(syncv1.ConflictPolicyOverwrite)
+			Expect(target.Data).To(HaveKeyWithValue("password", []byte("s3cr3t")))
+			Expect(target.Labels).NotTo(HaveKey(managedByLabel))
+			Expect(target.Labels).NotTo(HaveKey(ownerNameLabel))
+			Expect(target.OwnerReferences).To(BeEmpty())
+			Expect(resource.Status.Targets[0].State).To(Equal(syncv1.TargetStateSynced))
+		})
+	})
+
+	Context("When a resync is requested through the annotation", func() {
+		const (
+			resourceName = "resync-resource"
+			sourceName   = "resync-source"
+			targetName   = "resync-target"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		BeforeEach(func() {
+			createSource(ctx, sourceName)
+		})
+
+		// requestResync 设置 resync-at 注解并调和一次，返回调和后的 Secretsync
+		requestResync := func(value string) *syncv1.Secretsync {
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Annotations = map[string]string{resyncAtAnnotation: value}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			_, err := newTestReconciler().Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			return resource
+		}
+
+		It("should record the handled annotation value", func() {
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       testSpec(sourceName, targetName),
+			})
+			_, err := newTestReconciler().Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := requestResync("2026-01-01T00:00:00Z")
+			Expect(resource.Status.LastHandledResyncAt).To(Equal("2026-01-01T00:00:00Z"))
+			Expect(resource.Status.Targets).To(HaveLen(1))
+			Expect(resource.Status.Targets[0].State).To(Equal(syncv1.TargetStateSynced))
+
+			resource = requestResync("2026-01-02T00:00:00Z")
+			Expect(resource.Status.LastHandledResyncAt).To(Equal("2026-01-02T00:00:00Z"))
+		})
+
+		It("should not record the annotation while a target cannot be rewritten", func() {
+			By("creating an unmanaged Secret with the target name")
+			existing := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: targetName, Namespace: "default"},
+				Data:       map[string][]byte{"password": []byte("mine")},
+			}
+			Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, existing))).To(Succeed())
+			})
+			spec := testSpec(sourceName, targetName)
+			spec.ConflictPolicy = syncv1.ConflictPolicySkip
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       spec,
+			})
+
+			resource := requestResync("2026-01-01T00:00:00Z")
+			Expect(resource.Status.Targets).To(HaveLen(1))
+			Expect(resource.Status.Targets[0].State).To(Equal(syncv1.TargetStateConflicted))
+			Expect(resource.Status.LastHandledResyncAt).To(BeEmpty())
+		})
+	})
+
+	Context("When deleting a resource with the Orphan policy", func() {
+		const (
+			resourceName = "orphan-resource"
+			sourceName   = "orphan-source"
+			targetName   = "orphan-target"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		targetKey := types.NamespacedName{Name: targetName, Namespace: "default"}
+
+		BeforeEach(func() {
+			createSource(ctx, sourceName)
+		})
+
+		It("should leave an unmanaged copy behind", func() {
+			controllerReconciler := newTestReconciler()
+			spec := testSpec(sourceName, targetName)
+			spec.DeletionPolicy = syncv1.DeletionPolicyOrphan
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       spec,
+			})
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Deleting the resource")
+			resource := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			target := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, targetKey, target)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, target))).To(Succeed())
+			})
+			Expect(isManaged(target)).To(BeFalse())
+			for _, label := range []string{managedByLabel, sourceNamespaceLabel, sourceNameLabel,
+				ownerKindLabel, ownerNamespaceLabel, ownerNameLabel, watchedLabel} {
+				Expect(target.Labels).NotTo(HaveKey(label))
+			}
+			Expect(target.OwnerReferences).To(BeEmpty())
+
+			By("Creating a new Secretsync with the Skip policy for the same target")
+			spec = testSpec(sourceName, targetName)
+			spec.ConflictPolicy = syncv1.ConflictPolicySkip
+			createSyncObject(ctx, &syncv1.Secretsync{
+				ObjectMeta: metav1.ObjectMeta{Name: "orphan-successor", Namespace: "default"},
+				Spec:       spec,
+			})
+			successorKey := types.NamespacedName{Name: "orphan-successor", Namespace: "default"}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: successorKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			successor := &syncv1.Secretsync{}
+			Expect(k8sClient.Get(ctx, successorKey, successor)).To(Succeed())
+			Expect(successor.Status.Targets).To(HaveLen(1))
+			Expect(successor.Status.Targets[0].State).To(Equal(syncv1.TargetStateConflicted))
 		})
 	})
 })
@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	syncv1 "github.com/stangj/secretsync-controller/api/v1"
+)
+
+// setCondition 设置一个状态条件，并记录其对应的 generation
+func setCondition(syncObj syncObject, condType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&syncObj.SyncStatus().Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: syncObj.GetGeneration(),
+	})
+}
+
+// markProgressing 标记控制器开始处理新的 generation
+func markProgressing(syncObj syncObject) {
+	setCondition(syncObj, syncv1.ConditionProgressing, metav1.ConditionTrue,
+		syncv1.ReasonReconciling, "Reconciling new generation")
+}
+
+// markSuspended 标记同步已暂停，Progressing 随之结束
+func markSuspended(syncObj syncObject) {
+	message := "Synchronization is suspended by spec.suspend"
+	setCondition(syncObj, syncv1.ConditionSuspended, metav1.ConditionTrue, syncv1.ReasonSuspended, message)
+	setCondition(syncObj, syncv1.ConditionProgressing, metav1.ConditionFalse, syncv1.ReasonSuspended, message)
+	syncObj.SyncStatus().ObservedGeneration = syncObj.GetGeneration()
+}
+
+// clearSuspended 在恢复同步后移除 Suspended 条件
+func clearSuspended(syncObj syncObject) {
+	meta.RemoveStatusCondition(&syncObj.SyncStatus().Conditions, syncv1.ConditionSuspended)
+}
+
+// markFailed 标记本次调和失败：Ready=False、Degraded=True，并结束 Progressing
+func markFailed(syncObj syncObject, reason, message string) {
+	setCondition(syncObj, syncv1.ConditionReady, metav1.ConditionFalse, reason, message)
+	setCondition(syncObj, syncv1.ConditionDegraded, metav1.ConditionTrue, reason, message)
+	setCondition(syncObj, syncv1.ConditionProgressing, metav1.ConditionFalse, reason, message)
+	syncObj.SyncStatus().ObservedGeneration = syncObj.GetGeneration()
+}
+
+// markDrifted 标记 Audit 或 DryRun 模式下存在未应用的变更：Ready=False，但不视为 Degraded
+func markDrifted(syncObj syncObject, reason, message string) {
+	setCondition(syncObj, syncv1.ConditionReady, metav1.ConditionFalse, reason, message)
+	setCondition(syncObj, syncv1.ConditionDegraded, metav1.ConditionFalse, reason, message)
+	setCondition(syncObj, syncv1.ConditionProgressing, metav1.ConditionFalse,
+		syncv1.ReasonReconcileComplete, "Reconciliation complete")
+	syncObj.SyncStatus().ObservedGeneration = syncObj.GetGeneration()
+}
+
+// markReady 标记所有目标均已同步：Ready=True、Degraded=False，并结束 Progressing
+func markReady(syncObj syncObject, message string) {
+	setCondition(syncObj, syncv1.ConditionReady, metav1.ConditionTrue, syncv1.ReasonSynced, message)
+	setCondition(syncObj, syncv1.ConditionDegraded, metav1.ConditionFalse, syncv1.ReasonSynced, message)
+	setCondition(syncObj, syncv1.ConditionProgressing, metav1.ConditionFalse,
+		syncv1.ReasonReconcileComplete, "Reconciliation complete")
+	syncObj.SyncStatus().ObservedGeneration = syncObj.GetGeneration()
+}
+
+// targetRecorder 收集本次调和中每个目标的同步结果
+// 状态未变化时沿用上一次的 LastTransitionTime，失败时沿用上一次写入的哈希
+type targetRecorder struct {
+	prev    map[string]syncv1.TargetStatus
+	now     metav1.Time
+	targets []syncv1.TargetStatus
+}
+
+// newTargetRecorder 基于上一次的目标状态创建 targetRecorder
+func newTargetRecorder(prev []syncv1.TargetStatus, now metav1.Time) *targetRecorder {
+	m := make(map[string]syncv1.TargetStatus, len(prev))
+	for _, t := range prev {
+		m[t.Namespace+"/"+t.Name] = t
+	}
+	return &targetRecorder{prev: m, now: now}
+}
+
+// record 记录一个目标的同步结果
+func (t *targetRecorder) record(namespace, name string, state syncv1.TargetState, message, hash string) {
+	entry := syncv1.TargetStatus{
+		Namespace:          namespace,
+		Name:               name,
+		State:              state,
+		Message:            message,
+		Hash:               hash,
+		LastTransitionTime: t.now,
+	}
+	if prev, ok := t.prev[namespace+"/"+name]; ok {
+		if prev.State == state {
+			entry.LastTransitionTime = prev.LastTransitionTime
+		}
+		if hash == "" {
+			entry.Hash = prev.Hash
+		}
+	}
+	t.targets = append(t.targets, entry)
+}
+
+// fail 记录一个目标的失败，并根据连续失败次数计算下一次重试时间
+// hash 是本次期望写入的数据哈希；retriable 为 false 时不安排重试
+func (t *targetRecorder) fail(namespace, name, message, hash string, retriable bool) {
+	t.record(namespace, name, syncv1.TargetStateFailed, message, "")
+	entry := &t.targets[len(t.targets)-1]
+	entry.FailedHash = hash
+	entry.Attempts = 1
+	if prev, ok := t.prev[namespace+"/"+name]; ok && prev.State == syncv1.TargetStateFailed {
+		entry.Attempts = prev.Attempts + 1
+	}
+	if retriable {
+		next := metav1.NewTime(t.now.Add(targetRetryDelay(entry.Attempts)))
+		entry.NextRetryTime = &next
+	}
+}
+
+// carry 原样沿用上一次记录的目标状态，用于限定范围的调和中未检查的目标
+// 没有上一次的记录时返回 false
+func (t *targetRecorder) carry(namespace, name string) (syncv1.TargetStatus, bool) {
+	prev, ok := t.prev[namespace+"/"+name]
+	if ok {
+		t.targets = append(t.targets, prev)
+	}
+	return prev, ok
+}
+
+// result 返回按命名空间和名称排序后的目标状态列表
+func (t *targetRecorder) result() []syncv1.TargetStatus {
+	sort.SliceStable(t.targets, func(i, j int) bool {
+		if t.targets[i].Namespace != t.targets[j].Namespace {
+			return t.targets[i].Namespace < t.targets[j].Namespace
+		}
+		return t.targets[i].Name < t.targets[j].Name
+	})
+	return t.targets
+}
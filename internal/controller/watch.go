@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// sourceIndexField 按 "<sourceNamespace>/<sourceSecretName>" 索引同步对象
+	sourceIndexField = "spec.sourceRef"
+	// targetNamespaceIndexField 按显式目标命名空间索引同步对象
+	// 使用选择器、名称模式或表达式的对象额外以 dynamicTargetsIndexValue 索引
+	targetNamespaceIndexField = "spec.targetNamespaces"
+	// dynamicTargetsIndexValue 不是合法的命名空间名称，不会与显式目标冲突
+	dynamicTargetsIndexValue = "*"
+)
+
+// listFunc 按条件列出同步对象，由 Secretsync 与 ClusterSecretsync 各自实现
+type listFunc func(ctx context.Context, opts ...client.ListOption) ([]syncObject, error)
+
+// setupIndexes 为同步对象类型注册事件映射使用的字段索引
+func setupIndexes(mgr ctrl.Manager, obj client.Object) error {
+	indexer := mgr.GetFieldIndexer()
+	if err := indexer.IndexField(context.Background(), obj, sourceIndexField, indexSource); err != nil {
+		return err
+	}
+	return indexer.IndexField(context.Background(), obj, targetNamespaceIndexField, indexTargetNamespaces)
+}
+
+// sourceIndexValue 返回源 Secret 在 sourceIndexField 中的取值
+func sourceIndexValue(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// indexSource 是 sourceIndexField 的索引函数
+func indexSource(obj client.Object) []string {
+	spec := obj.(syncObject).SyncSpec()
+	if spec.SourceNamespace == "" || spec.SourceSecretName == "" {
+		return nil
+	}
+	return []string{sourceIndexValue(spec.SourceNamespace, spec.SourceSecretName)}
+}
+
+// indexTargetNamespaces 是 targetNamespaceIndexField 的索引函数
+func indexTargetNamespaces(obj client.Object) []string {
+	spec := obj.(syncObject).SyncSpec()
+	values := append([]string(nil), spec.TargetNamespaces...)
+	if spec.TargetNamespaceSelector != nil || len(spec.TargetNamespacePatterns) > 0 ||
+		spec.TargetNamespaceExpression != "" {
+		values = append(values, dynamicTargetsIndexValue)
+	}
+	return values
+}
+
+// listNamespaceCandidates 通过索引列出可能以该命名空间为目标的同步对象
+// 结果仍需经过 requestsForNamespace 做完整判断
+func listNamespaceCandidates(ctx context.Context, namespace string, list listFunc) ([]syncObject, error) {
+	explicit, err := list(ctx, client.MatchingFields{targetNamespaceIndexField: namespace})
+	if err != nil {
+		return nil, err
+	}
+	dynamic, err := list(ctx, client.MatchingFields{targetNamespaceIndexField: dynamicTargetsIndexValue})
+	if err != nil {
+		return nil, err
+	}
+
+	// 同时使用显式列表与选择器的对象会出现在两次结果中
+	seen := make(map[client.ObjectKey]struct{}, len(explicit))
+	items := make([]syncObject, 0, len(explicit)+len(dynamic))
+	for _, item := range append(explicit, dynamic...) {
+		key := client.ObjectKeyFromObject(item)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// secretPredicate 过滤与同步无关的 Secret 事件
+// ServiceAccount token Secret 的频繁变化不会到达事件映射；
+// 更新事件只有在数据、类型、标签或注解变化时才需要处理
+func secretPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isRelevantSecret(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isRelevantSecret(e.Object) },
+		GenericFunc: func(e event.GenericEvent) bool { return isRelevantSecret(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if !isRelevantSecret(e.ObjectNew) {
+				return false
+			}
+			oldSecret, newSecret := e.ObjectOld.(*corev1.Secret), e.ObjectNew.(*corev1.Secret)
+			return !dataEqual(oldSecret.Data, newSecret.Data) || oldSecret.Type != newSecret.Type ||
+				!maps.Equal(oldSecret.Labels, newSecret.Labels) ||
+				!maps.Equal(oldSecret.Annotations, newSecret.Annotations)
+		},
+	}
+}
+
+// isRelevantSecret 判断 Secret 是否可能是源或目标
+func isRelevantSecret(obj client.Object) bool {
+	secret, ok := obj.(*corev1.Secret)
+	return ok && secret.Type != corev1.SecretTypeServiceAccountToken
+}
+
+// namespacePredicate 只保留会影响目标选择的 Namespace 事件：
+// 创建、删除，以及标签或注解的变化（选择器与 CEL 表达式依赖它们）
+func namespacePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !maps.Equal(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) ||
+				!maps.Equal(e.ObjectOld.GetAnnotations(), e.ObjectNew.GetAnnotations())
+		},
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	syncv1 "github.com/stangj/secretsync-controller/api/v1"
+)
+
+var _ = Describe("Watch indexes and predicates", func() {
+	It("should index sources and explicit or dynamic targets", func() {
+		obj := &syncv1.Secretsync{Spec: syncv1.SecretsyncSpec{
+			SourceNamespace:         "default",
+			SourceSecretName:        "db",
+			TargetNamespaces:        []string{"team-a"},
+			TargetNamespaceSelector: &metav1.LabelSelector{},
+		}}
+		Expect(indexSource(obj)).To(Equal([]string{"default/db"}))
+		Expect(indexTargetNamespaces(obj)).To(Equal([]string{"team-a", dynamicTargetsIndexValue}))
+	})
+
+	It("should drop Secret updates that do not change synced content", func() {
+		p := secretPredicate()
+		old := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", ResourceVersion: "1"},
+			Data:       map[string][]byte{"password": []byte("a")},
+		}
+		touched := old.DeepCopy()
+		touched.ResourceVersion = "2"
+		Expect(p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: touched})).To(BeFalse())
+
+		changed := touched.DeepCopy()
+		changed.Data["password"] = []byte("b")
+		Expect(p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: changed})).To(BeTrue())
+
+		token := &corev1.Secret{Type: corev1.SecretTypeServiceAccountToken}
+		Expect(p.Create(event.CreateEvent{Object: token})).To(BeFalse())
+	})
+})